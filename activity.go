@@ -0,0 +1,358 @@
+package anilistgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	ActivityTypeText      = "TEXT"
+	ActivityTypeAnimeList = "ANIME_LIST"
+	ActivityTypeMangaList = "MANGA_LIST"
+	ActivityTypeMessage   = "MESSAGE"
+	ActivityTypeMediaList = "MEDIA_LIST"
+
+	ActivitiesQuery = `
+    query ($page: Int, $perPage: Int, $userId: Int, $type: ActivityType, $createdAtGreater: Int, $createdAtLesser: Int, $isFollowing: Boolean) {
+      Page (page: $page, perPage: $perPage) {
+        pageInfo {
+          total
+          perPage
+          currentPage
+          lastPage
+          hasNextPage
+        }
+        activities (userId: $userId, type: $type, createdAt_greater: $createdAtGreater, createdAt_lesser: $createdAtLesser, isFollowing: $isFollowing, sort: ID_DESC) {
+          __typename
+          ... on ListActivity {
+            id
+            userId
+            type
+            status
+            progress
+            isLocked
+            isSubscribed
+            likeCount
+            replyCount
+            siteUrl
+            createdAt
+            user {
+              id
+              name
+            }
+            media {
+              id
+              type
+              title {
+                romaji
+                english
+                native
+              }
+              coverImage {
+                extraLarge
+              }
+            }
+          }
+          ... on TextActivity {
+            id
+            userId
+            type
+            text
+            isLocked
+            isSubscribed
+            likeCount
+            replyCount
+            siteUrl
+            createdAt
+            user {
+              id
+              name
+            }
+          }
+          ... on MessageActivity {
+            id
+            recipientId
+            messengerId
+            type
+            message
+            isPrivate
+            isLocked
+            isSubscribed
+            likeCount
+            replyCount
+            siteUrl
+            createdAt
+            messenger {
+              id
+              name
+            }
+            recipient {
+              id
+              name
+            }
+          }
+        }
+      }
+    }
+    `
+
+	SaveTextActivityQuery = `
+    mutation ($text: String) {
+      SaveTextActivity (text: $text) {
+        id
+        userId
+        type
+        text
+        likeCount
+        replyCount
+        siteUrl
+        createdAt
+      }
+    }
+    `
+
+	DeleteActivityQuery = `
+    mutation ($id: Int) {
+      DeleteActivity (id: $id) {
+        deleted
+      }
+    }
+    `
+)
+
+// Activity is implemented by ListActivity, TextActivity and MessageActivity.
+// Use a type switch to access the fields of a specific activity type.
+type Activity interface {
+	ActivityID() int
+	ActivityCreatedAt() int64
+}
+
+// ActivityUnion decodes any of the activity types returned by AniList, based
+// on the __typename field of the response.
+type ActivityUnion struct {
+	Activity Activity
+}
+
+type ListActivity struct {
+	ID           int      `json:"id"`
+	UserID       int      `json:"userId"`
+	Type         string   `json:"type"`
+	Status       string   `json:"status"`
+	Progress     string   `json:"progress"`
+	IsLocked     bool     `json:"isLocked"`
+	IsSubscribed bool     `json:"isSubscribed"`
+	LikeCount    int      `json:"likeCount"`
+	ReplyCount   int      `json:"replyCount"`
+	SiteURL      string   `json:"siteUrl"`
+	CreatedAt    int64    `json:"createdAt"`
+	User         UserInfo `json:"user"`
+	Media        Media    `json:"media"`
+}
+
+type TextActivity struct {
+	ID           int      `json:"id"`
+	UserID       int      `json:"userId"`
+	Type         string   `json:"type"`
+	Text         string   `json:"text"`
+	IsLocked     bool     `json:"isLocked"`
+	IsSubscribed bool     `json:"isSubscribed"`
+	LikeCount    int      `json:"likeCount"`
+	ReplyCount   int      `json:"replyCount"`
+	SiteURL      string   `json:"siteUrl"`
+	CreatedAt    int64    `json:"createdAt"`
+	User         UserInfo `json:"user"`
+}
+
+type MessageActivity struct {
+	ID           int      `json:"id"`
+	RecipientID  int      `json:"recipientId"`
+	MessengerID  int      `json:"messengerId"`
+	Type         string   `json:"type"`
+	Message      string   `json:"message"`
+	IsPrivate    bool     `json:"isPrivate"`
+	IsLocked     bool     `json:"isLocked"`
+	IsSubscribed bool     `json:"isSubscribed"`
+	LikeCount    int      `json:"likeCount"`
+	ReplyCount   int      `json:"replyCount"`
+	SiteURL      string   `json:"siteUrl"`
+	CreatedAt    int64    `json:"createdAt"`
+	Messenger    UserInfo `json:"messenger"`
+	Recipient    UserInfo `json:"recipient"`
+}
+
+// ActivityFilter narrows down the activities returned by GetActivities. Zero
+// values are not sent to the API.
+type ActivityFilter struct {
+	UserID        int
+	Type          string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	IsFollowing   *bool
+}
+
+// UnknownActivity is an activity type the package does not model. Raw holds
+// the activity as returned by AniList.
+type UnknownActivity struct {
+	Typename  string          `json:"__typename"`
+	ID        int             `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt int64           `json:"createdAt"`
+	Raw       json.RawMessage `json:"-"`
+}
+
+func (a ListActivity) ActivityID() int             { return a.ID }
+func (a ListActivity) ActivityCreatedAt() int64    { return a.CreatedAt }
+func (a TextActivity) ActivityID() int             { return a.ID }
+func (a TextActivity) ActivityCreatedAt() int64    { return a.CreatedAt }
+func (a MessageActivity) ActivityID() int          { return a.ID }
+func (a MessageActivity) ActivityCreatedAt() int64 { return a.CreatedAt }
+func (a UnknownActivity) ActivityID() int          { return a.ID }
+func (a UnknownActivity) ActivityCreatedAt() int64 { return a.CreatedAt }
+
+func (u *ActivityUnion) UnmarshalJSON(data []byte) error {
+	var typename struct {
+		Typename string `json:"__typename"`
+	}
+	if err := json.Unmarshal(data, &typename); err != nil {
+		return err
+	}
+
+	switch typename.Typename {
+	case "ListActivity":
+		var activity ListActivity
+		if err := json.Unmarshal(data, &activity); err != nil {
+			return err
+		}
+		u.Activity = activity
+	case "TextActivity":
+		var activity TextActivity
+		if err := json.Unmarshal(data, &activity); err != nil {
+			return err
+		}
+		u.Activity = activity
+	case "MessageActivity":
+		var activity MessageActivity
+		if err := json.Unmarshal(data, &activity); err != nil {
+			return err
+		}
+		u.Activity = activity
+	default:
+		activity := UnknownActivity{Typename: typename.Typename, Raw: append(json.RawMessage(nil), data...)}
+		if err := json.Unmarshal(data, &activity); err != nil {
+			return err
+		}
+		u.Activity = activity
+	}
+
+	return nil
+}
+
+// GetActivities retrieves one page of public activities on AniList matching
+// the given filter, newest first.
+//
+// Parameters:
+//   - filter: The ActivityFilter to apply. IsFollowing only has an effect for
+//     authenticated requests, see AuthenticatedAPI.GetActivities.
+//   - page: The page to fetch, starting at 1.
+//   - perPage: The number of activities per page, at most 50.
+//
+// Returns:
+//   - A slice of Activity values, each being a ListActivity, TextActivity or
+//     MessageActivity.
+//   - The PageInfo of the fetched page, to know whether there is a next page.
+//   - An error if there's any issue fetching the data.
+//
+// Usage:
+//
+//	activities, pageInfo, err := GetActivities(ActivityFilter{UserID: 12345}, 1, PerPage)
+//	for _, activity := range activities {
+//	    if list, ok := activity.(ListActivity); ok {
+//	        fmt.Println(list.Status, list.Progress, list.Media.Title.Romaji)
+//	    }
+//	}
+func GetActivities(filter ActivityFilter, page int, perPage int) ([]Activity, PageInfo, error) {
+	return fetchActivities(filter, page, perPage, "")
+}
+
+// GetActivities retrieves one page of activities like the package level
+// GetActivities, but as the authenticated user. This is required for the
+// IsFollowing filter, which restricts the activities to users followed by the
+// authenticated user.
+func (api *AuthenticatedAPI) GetActivities(filter ActivityFilter, page int, perPage int) ([]Activity, PageInfo, error) {
+	return fetchActivities(filter, page, perPage, api.AccessToken)
+}
+
+// SaveTextActivity posts a new text activity on the authenticated user's
+// profile and returns the created activity.
+//
+// Usage:
+//
+//	activity, err := api.SaveTextActivity("Finally caught up with One Piece!")
+func (api *AuthenticatedAPI) SaveTextActivity(text string) (TextActivity, error) {
+	variables := map[string]interface{}{
+		"text": text,
+	}
+
+	data, err := sendRequest(BaseAPIURL, SaveTextActivityQuery, variables, api.AccessToken)
+	if err != nil {
+		return TextActivity{}, err
+	}
+	if data.Data.SaveTextActivity == nil {
+		return TextActivity{}, fmt.Errorf("text activity was not saved")
+	}
+	return *data.Data.SaveTextActivity, nil
+}
+
+// DeleteActivity deletes one of the authenticated user's activities. It
+// returns an error if AniList did not confirm the deletion.
+func (api *AuthenticatedAPI) DeleteActivity(id int) error {
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	data, err := sendRequest(BaseAPIURL, DeleteActivityQuery, variables, api.AccessToken)
+	if err != nil {
+		return err
+	}
+	if data.Data.DeleteActivity == nil || !data.Data.DeleteActivity.Deleted {
+		return fmt.Errorf("activity %d was not deleted", id)
+	}
+	return nil
+}
+
+func fetchActivities(filter ActivityFilter, page int, perPage int, accessToken string) ([]Activity, PageInfo, error) {
+	variables := map[string]interface{}{
+		"page":    page,
+		"perPage": perPage,
+	}
+	if filter.UserID != 0 {
+		variables["userId"] = filter.UserID
+	}
+	if filter.Type != "" {
+		variables["type"] = filter.Type
+	}
+	if !filter.CreatedAfter.IsZero() {
+		variables["createdAtGreater"] = filter.CreatedAfter.Unix()
+	}
+	if !filter.CreatedBefore.IsZero() {
+		variables["createdAtLesser"] = filter.CreatedBefore.Unix()
+	}
+	if filter.IsFollowing != nil {
+		variables["isFollowing"] = *filter.IsFollowing
+	}
+
+	data, err := sendRequest(BaseAPIURL, ActivitiesQuery, variables, accessToken)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	if data.Data.Page == nil {
+		return nil, PageInfo{}, nil
+	}
+
+	activities := make([]Activity, 0, len(data.Data.Page.Activities))
+	for _, activity := range data.Data.Page.Activities {
+		activities = append(activities, activity.Activity)
+	}
+	return activities, data.Data.Page.PageInfo, nil
+}
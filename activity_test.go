@@ -0,0 +1,52 @@
+package anilistgo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestActivityUnionUnmarshal(t *testing.T) {
+	body := `{"activities": [
+		{"__typename": "ListActivity", "id": 1, "status": "watched episode", "progress": "5", "media": {"id": 21}},
+		{"__typename": "TextActivity", "id": 2, "text": "Hello"},
+		{"__typename": "MessageActivity", "id": 3, "message": "Hi", "messenger": {"id": 4, "name": "Ithilias"}}
+	]}`
+
+	var page PageData
+	if err := json.Unmarshal([]byte(body), &page); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(page.Activities) != 3 {
+		t.Fatalf("expected 3 activities but got %d", len(page.Activities))
+	}
+	if list, ok := page.Activities[0].Activity.(ListActivity); !ok || list.Media.ID != 21 || list.Progress != "5" {
+		t.Errorf("expected list activity for media 21 but got %#v", page.Activities[0].Activity)
+	}
+	if text, ok := page.Activities[1].Activity.(TextActivity); !ok || text.Text != "Hello" {
+		t.Errorf("expected text activity but got %#v", page.Activities[1].Activity)
+	}
+	if message, ok := page.Activities[2].Activity.(MessageActivity); !ok || message.Messenger.Name != "Ithilias" {
+		t.Errorf("expected message activity but got %#v", page.Activities[2].Activity)
+	}
+
+	var unknown ActivityUnion
+	if err := json.Unmarshal([]byte(`{"__typename": "PollActivity", "id": 5, "type": "POLL"}`), &unknown); err != nil {
+		t.Fatalf("expected an unknown activity type to be decoded but got: %v", err)
+	}
+	if activity, ok := unknown.Activity.(UnknownActivity); !ok || activity.ActivityID() != 5 || activity.Typename != "PollActivity" || len(activity.Raw) == 0 {
+		t.Errorf("expected an UnknownActivity with its ID and type but got %#v", unknown.Activity)
+	}
+}
+
+func TestCollectPages(t *testing.T) {
+	items, err := CollectPages(func(page int) ([]int, PageInfo, error) {
+		return []int{page}, PageInfo{CurrentPage: page, HasNextPage: page < 3}, nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if len(items) != 3 || items[2] != 3 {
+		t.Errorf("expected items from 3 pages but got %v", items)
+	}
+}
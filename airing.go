@@ -0,0 +1,207 @@
+package anilistgo
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	AiringSchedulesQuery = `
+    query ($page: Int, $perPage: Int, $airingAtGreater: Int, $airingAtLesser: Int, $mediaIdIn: [Int], $notYetAired: Boolean) {
+      Page (page: $page, perPage: $perPage) {
+        pageInfo {
+          total
+          perPage
+          currentPage
+          lastPage
+          hasNextPage
+        }
+        airingSchedules (airingAt_greater: $airingAtGreater, airingAt_lesser: $airingAtLesser, mediaId_in: $mediaIdIn, notYetAired: $notYetAired, sort: TIME) {
+          id
+          airingAt
+          timeUntilAiring
+          episode
+          mediaId
+          media {
+            id
+            title {
+              romaji
+              english
+              native
+            }
+            coverImage {
+              extraLarge
+            }
+            episodes
+            duration
+          }
+        }
+      }
+    }
+    `
+)
+
+type AiringSchedule struct {
+	ID              int    `json:"id"`
+	AiringAt        int64  `json:"airingAt"`
+	TimeUntilAiring int64  `json:"timeUntilAiring"`
+	Episode         int    `json:"episode"`
+	MediaID         int    `json:"mediaId"`
+	Media           *Media `json:"media,omitempty"`
+}
+
+// AiringScheduleFilter narrows down the schedules returned by
+// GetAiringSchedules. Zero values are not sent to the API.
+type AiringScheduleFilter struct {
+	AiringAfter  time.Time
+	AiringBefore time.Time
+	MediaIDs     []int
+	NotYetAired  *bool
+}
+
+// UpcomingEpisode pairs the airing schedule of an episode with the list update
+// of the user that is watching it.
+type UpcomingEpisode struct {
+	Update   Update
+	Schedule AiringSchedule
+}
+
+// AiringTime returns the time at which the episode airs.
+func (s AiringSchedule) AiringTime() time.Time {
+	return time.Unix(s.AiringAt, 0)
+}
+
+// Countdown returns the time left until the episode airs, relative to now. It
+// is negative if the episode has already aired.
+func (s AiringSchedule) Countdown(now time.Time) time.Duration {
+	return s.AiringTime().Sub(now)
+}
+
+// GetAiringSchedules retrieves one page of airing schedules matching the
+// given filter, sorted by airing time.
+//
+// Parameters:
+//   - filter: The AiringScheduleFilter to apply.
+//   - page: The page to fetch, starting at 1.
+//   - perPage: The number of schedules per page, at most 50.
+//
+// Returns:
+//   - A slice of AiringSchedule structs, including the media they belong to.
+//   - The PageInfo of the fetched page, to know whether there is a next page.
+//   - An error if there's any issue fetching the data.
+//
+// Usage:
+//
+//	notYetAired := true
+//	filter := AiringScheduleFilter{MediaIDs: []int{21}, NotYetAired: &notYetAired}
+//	schedules, _, err := GetAiringSchedules(filter, 1, PerPage)
+func GetAiringSchedules(filter AiringScheduleFilter, page int, perPage int) ([]AiringSchedule, PageInfo, error) {
+	variables := map[string]interface{}{
+		"page":    page,
+		"perPage": perPage,
+	}
+	if !filter.AiringAfter.IsZero() {
+		variables["airingAtGreater"] = filter.AiringAfter.Unix()
+	}
+	if !filter.AiringBefore.IsZero() {
+		variables["airingAtLesser"] = filter.AiringBefore.Unix()
+	}
+	if len(filter.MediaIDs) > 0 {
+		variables["mediaIdIn"] = filter.MediaIDs
+	}
+	if filter.NotYetAired != nil {
+		variables["notYetAired"] = *filter.NotYetAired
+	}
+
+	data, err := sendRequest(BaseAPIURL, AiringSchedulesQuery, variables, "")
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	if data.Data.Page == nil {
+		return nil, PageInfo{}, nil
+	}
+	return data.Data.Page.AiringSchedules, data.Data.Page.PageInfo, nil
+}
+
+// GetUpcomingEpisodes returns the episodes of the shows on a user's CURRENT
+// list that air within the given duration from now, sorted by airing time.
+//
+// Parameters:
+//   - updates: The user's anime updates, as returned by GetUpdates with
+//     MediaTypeAnime. Entries with another status are ignored.
+//   - within: How far ahead to look for airing episodes.
+//
+// Returns:
+//   - A slice of UpcomingEpisode structs, one per airing episode.
+//   - An error if there's any issue fetching the airing schedules.
+//
+// Usage:
+//
+//	updates, err := GetUpdates("Ithilias", MediaTypeAnime, nil, nil)
+//	upcoming, err := GetUpcomingEpisodes(updates, 24*time.Hour)
+//	for _, episode := range upcoming {
+//	    fmt.Printf("%s episode %d airs in %s\n", episode.Update.Title, episode.Schedule.Episode,
+//	        episode.Schedule.Countdown(time.Now()).Round(time.Minute))
+//	}
+func GetUpcomingEpisodes(updates []Update, within time.Duration) ([]UpcomingEpisode, error) {
+	now := time.Now()
+	notYetAired := true
+	filter := AiringScheduleFilter{
+		AiringBefore: now.Add(within),
+		NotYetAired:  &notYetAired,
+	}
+
+	return fetchUpcomingEpisodes(updatesByMediaID(updates, "CURRENT"), filter)
+}
+
+func fetchUpcomingEpisodes(updates map[int]Update, filter AiringScheduleFilter) ([]UpcomingEpisode, error) {
+	if len(updates) == 0 {
+		return nil, nil
+	}
+
+	for mediaID := range updates {
+		filter.MediaIDs = append(filter.MediaIDs, mediaID)
+	}
+	sort.Ints(filter.MediaIDs)
+
+	schedules, err := CollectPages(func(page int) ([]AiringSchedule, PageInfo, error) {
+		return GetAiringSchedules(filter, page, MaxPerPage)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return matchSchedules(updates, schedules), nil
+}
+
+func updatesByMediaID(updates []Update, statuses ...string) map[int]Update {
+	byID := make(map[int]Update)
+	for _, update := range updates {
+		if update.MediaType != "" && update.MediaType != MediaTypeAnime {
+			continue
+		}
+		for _, status := range statuses {
+			if update.Status == status {
+				byID[update.MediaID] = update
+				break
+			}
+		}
+	}
+	return byID
+}
+
+func matchSchedules(updates map[int]Update, schedules []AiringSchedule) []UpcomingEpisode {
+	var upcoming []UpcomingEpisode
+	for _, schedule := range schedules {
+		update, ok := updates[schedule.MediaID]
+		if !ok {
+			continue
+		}
+		upcoming = append(upcoming, UpcomingEpisode{Update: update, Schedule: schedule})
+	}
+
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		return upcoming[i].Schedule.AiringAt < upcoming[j].Schedule.AiringAt
+	})
+	return upcoming
+}
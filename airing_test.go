@@ -0,0 +1,39 @@
+package anilistgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMatchSchedules(t *testing.T) {
+	updates := updatesByMediaID([]Update{
+		{MediaID: 1, Title: "Current", Status: "CURRENT", MediaType: MediaTypeAnime},
+		{MediaID: 2, Title: "Planning", Status: "PLANNING", MediaType: MediaTypeAnime},
+		{MediaID: 3, Title: "Also current", Status: "CURRENT", MediaType: MediaTypeAnime},
+	}, "CURRENT")
+
+	if len(updates) != 2 {
+		t.Fatalf("expected 2 current updates but got %d", len(updates))
+	}
+
+	upcoming := matchSchedules(updates, []AiringSchedule{
+		{MediaID: 3, Episode: 7, AiringAt: 2000},
+		{MediaID: 2, Episode: 1, AiringAt: 500},
+		{MediaID: 1, Episode: 4, AiringAt: 1000},
+	})
+
+	if len(upcoming) != 2 {
+		t.Fatalf("expected 2 upcoming episodes but got %d", len(upcoming))
+	}
+	if upcoming[0].Update.Title != "Current" || upcoming[1].Schedule.Episode != 7 {
+		t.Errorf("expected upcoming episodes sorted by airing time but got %v", upcoming)
+	}
+}
+
+func TestAiringScheduleCountdown(t *testing.T) {
+	now := time.Unix(1000, 0)
+	schedule := AiringSchedule{AiringAt: 4600}
+	if schedule.Countdown(now) != time.Hour {
+		t.Errorf("expected countdown of 1h but got %v", schedule.Countdown(now))
+	}
+}
@@ -1,12 +1,9 @@
 package anilistgo
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"strings"
 	"time"
 )
 
@@ -15,10 +12,16 @@ const (
 	AnimeURLFormat   = "https://anilist.co/anime/%d"
 	AnilistURLFormat = "https://anilist.co/%s/%d"
 	PerPage          = 20
+	MaxPerPage       = 50
 	MediaTypeAnime   = "ANIME"
 	MediaTypeManga   = "MANGA"
 	Timeout          = 30
 
+	// AnimeSearchQueryWithSeason, AnimeSearchQueryByID and AnimeSearchQuery
+	// are kept for compatibility and select a fixed set of fields.
+	//
+	// Deprecated: FindAnilistItem and GetAnilistItemByID select every field of
+	// Media, see SelectionSet.
 	AnimeSearchQueryWithSeason = `
     query ($title: String, $season: MediaSeason, $seasonYear: Int) {
         Media (type: ANIME, search: $title, season: $season, seasonYear: $seasonYear) {
@@ -35,6 +38,26 @@ const (
 			chapters
 			volumes
             averageScore
+            nextAiringEpisode {
+                id
+                airingAt
+                timeUntilAiring
+                episode
+                mediaId
+            }
+            reviews (sort: [RATING_DESC, ID], perPage: 3) {
+                pageInfo {
+                    total
+                }
+                nodes {
+                    id
+                    score
+                    summary
+                    rating
+                    ratingAmount
+                    siteUrl
+                }
+            }
         }
     }
     `
@@ -55,6 +78,26 @@ const (
 			chapters
 			volumes
             averageScore
+            nextAiringEpisode {
+                id
+                airingAt
+                timeUntilAiring
+                episode
+                mediaId
+            }
+            reviews (sort: [RATING_DESC, ID], perPage: 3) {
+                pageInfo {
+                    total
+                }
+                nodes {
+                    id
+                    score
+                    summary
+                    rating
+                    ratingAmount
+                    siteUrl
+                }
+            }
         }
     }
     `
@@ -75,6 +118,26 @@ const (
 			chapters
 			volumes
             averageScore
+            nextAiringEpisode {
+                id
+                airingAt
+                timeUntilAiring
+                episode
+                mediaId
+            }
+            reviews (sort: [RATING_DESC, ID], perPage: 3) {
+                pageInfo {
+                    total
+                }
+                nodes {
+                    id
+                    score
+                    summary
+                    rating
+                    ratingAmount
+                    siteUrl
+                }
+            }
         }
     }
     `
@@ -125,6 +188,7 @@ const (
                         episodes
                         chapters
                         volumes
+                        duration
                     }
                     score (format: POINT_100)
                     progress
@@ -154,6 +218,7 @@ const (
                         episodes
                         chapters
                         volumes
+                        duration
                     }
                     score (format: POINT_100)
                     progress
@@ -178,6 +243,11 @@ const (
 )
 
 var (
+	// The media queries select every field of Media, see SelectionSet.
+	animeSearchQueryWithSeason = `query ($title: String, $season: MediaSeason, $seasonYear: Int) { Media (type: ANIME, search: $title, season: $season, seasonYear: $seasonYear) ` + SelectionSet(Media{}) + ` }`
+	animeSearchQueryByID       = `query ($id: Int) { Media (id: $id) ` + SelectionSet(Media{}) + ` }`
+	animeSearchQuery           = `query ($title: String) { Media (type: ANIME, search: $title) ` + SelectionSet(Media{}) + ` }`
+
 	AnimeSeasons          = []string{"WINTER", "SPRING", "SUMMER", "FALL", "WINTER"}
 	BeginningSeasonMonths = []int{1, 4, 7, 10}
 	EndSeasonMonths       = []int{3, 6, 9, 12}
@@ -195,24 +265,63 @@ type MediaTitle struct {
 
 type Media struct {
 	ID           int        `json:"id"`
+	IDMal        *int       `json:"idMal"`
+	Type         string     `json:"type"`
+	Format       string     `json:"format"`
 	AverageScore int        `json:"averageScore"`
 	Title        MediaTitle `json:"title"`
 	CoverImage   struct {
 		ExtraLarge string `json:"extraLarge"`
-	}
-	Episodes *int `json:"episodes"`
-	Chapters *int `json:"chapters"`
-	Volumes  *int `json:"volumes"`
+	} `json:"coverImage"`
+	Episodes          *int                      `json:"episodes"`
+	Chapters          *int                      `json:"chapters"`
+	Volumes           *int                      `json:"volumes"`
+	Duration          *int                      `json:"duration"`
+	Status            string                    `json:"status"`
+	Season            string                    `json:"season"`
+	SeasonYear        *int                      `json:"seasonYear"`
+	Genres            []string                  `json:"genres"`
+	MeanScore         int                       `json:"meanScore"`
+	Popularity        int                       `json:"popularity"`
+	Trending          int                       `json:"trending"`
+	Favourites        int                       `json:"favourites"`
+	SiteURL           string                    `json:"siteUrl"`
+	StartDate         FuzzyDate                 `json:"startDate"`
+	NextAiringEpisode *AiringSchedule           `json:"nextAiringEpisode"`
+	Relations         *MediaConnection          `json:"relations,omitempty" graphql:"-"`
+	Recommendations   *RecommendationConnection `json:"recommendations,omitempty" graphql:"-"`
+	Reviews           *ReviewConnection         `json:"reviews,omitempty" graphql:"(sort: [RATING_DESC, ID], perPage: 3)"`
 }
 
 type Response struct {
 	Data struct {
-		MediaData           Media                `json:"Media"`
-		MediaList           MediaList            `json:"MediaList"`
-		MediaListCollection *MediaListCollection `json:"MediaListCollection"`
-		User                UserInfo             `json:"User,omitempty"`
-		Page                *PageData            `json:"Page,omitempty"`
-		Errors              []struct {
+		MediaData                  Media                `json:"Media"`
+		MediaList                  MediaList            `json:"MediaList"`
+		MediaListCollection        *MediaListCollection `json:"MediaListCollection"`
+		User                       User                 `json:"User,omitempty"`
+		Page                       *PageData            `json:"Page,omitempty"`
+		SaveTextActivity           *TextActivity        `json:"SaveTextActivity,omitempty"`
+		DeleteActivity             *Deleted             `json:"DeleteActivity,omitempty"`
+		SaveMediaListEntry         *MediaListEntry      `json:"SaveMediaListEntry,omitempty"`
+		DeleteMediaListEntry       *Deleted             `json:"DeleteMediaListEntry,omitempty"`
+		Character                  *Character           `json:"Character,omitempty"`
+		Staff                      *Staff               `json:"Staff,omitempty"`
+		Studio                     *Studio              `json:"Studio,omitempty"`
+		Review                     *Review              `json:"Review,omitempty"`
+		SaveReview                 *Review              `json:"SaveReview,omitempty"`
+		DeleteReview               *Deleted             `json:"DeleteReview,omitempty"`
+		RateReview                 *Review              `json:"RateReview,omitempty"`
+		Thread                     *Thread              `json:"Thread,omitempty"`
+		SaveThread                 *Thread              `json:"SaveThread,omitempty"`
+		DeleteThread               *Deleted             `json:"DeleteThread,omitempty"`
+		SaveThreadComment          *ThreadComment       `json:"SaveThreadComment,omitempty"`
+		DeleteThreadComment        *Deleted             `json:"DeleteThreadComment,omitempty"`
+		ToggleThreadSubscription   *Thread              `json:"ToggleThreadSubscription,omitempty"`
+		ToggleLikeV2               *LikeStatus          `json:"ToggleLikeV2,omitempty"`
+		SaveActivityReply          *ActivityReply       `json:"SaveActivityReply,omitempty"`
+		DeleteActivityReply        *Deleted             `json:"DeleteActivityReply,omitempty"`
+		ToggleActivitySubscription *ActivityUnion       `json:"ToggleActivitySubscription,omitempty"`
+		Errors                     []struct {
 			Message string `json:"message"`
 			Status  int    `json:"status"`
 		} `json:"errors,omitempty"`
@@ -223,31 +332,55 @@ type MediaList struct {
 	Progress int `json:"progress"`
 }
 
-type MediaListCollection struct {
-	Lists []struct {
-		Entries []struct {
-			MediaID         int    `json:"mediaId"`
-			Score           int    `json:"score"`
-			Progress        *int   `json:"progress"`
-			ProgressVolumes *int   `json:"progressVolumes"`
-			Status          string `json:"status"`
-			UpdatedAt       int64  `json:"updatedAt"`
-			Media           Media  `json:"media"`
-		} `json:"entries"`
-	} `json:"lists"`
+type MediaListEntry struct {
+	ID              int       `json:"id"`
+	MediaID         int       `json:"mediaId"`
+	Score           int       `json:"score"`
+	Progress        *int      `json:"progress"`
+	ProgressVolumes *int      `json:"progressVolumes"`
+	Status          string    `json:"status"`
+	Repeat          int       `json:"repeat"`
+	Priority        int       `json:"priority"`
+	Private         bool      `json:"private"`
+	Notes           string    `json:"notes"`
+	StartedAt       FuzzyDate `json:"startedAt"`
+	CompletedAt     FuzzyDate `json:"completedAt"`
+	UpdatedAt       int64     `json:"updatedAt"`
+	CreatedAt       int64     `json:"createdAt"`
+	Media           Media     `json:"media"`
+}
+
+type Deleted struct {
+	Deleted bool `json:"deleted"`
 }
 
 type UserInfo struct {
-	ID int `json:"id"`
+	ID   int    `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+type PageInfo struct {
+	Total       int  `json:"total"`
+	PerPage     int  `json:"perPage"`
+	CurrentPage int  `json:"currentPage"`
+	LastPage    int  `json:"lastPage"`
+	HasNextPage bool `json:"hasNextPage"`
 }
 
 type PageData struct {
-	PageInfo struct {
-		HasNextPage bool `json:"hasNextPage"`
-	} `json:"pageInfo"`
-	Users []struct {
-		Name string `json:"name"`
-	} `json:"users"`
+	PageInfo        PageInfo            `json:"pageInfo"`
+	Users           []UserInfo          `json:"users"`
+	Activities      []ActivityUnion     `json:"activities"`
+	Notifications   []NotificationUnion `json:"notifications"`
+	AiringSchedules []AiringSchedule    `json:"airingSchedules"`
+	Characters      []Character         `json:"characters"`
+	Staff           []Staff             `json:"staff"`
+	Studios         []Studio            `json:"studios"`
+	Recommendations []Recommendation    `json:"recommendations"`
+	Media           []Media             `json:"media"`
+	Reviews         []Review            `json:"reviews"`
+	Threads         []Thread            `json:"threads"`
+	ThreadComments  []ThreadComment     `json:"threadComments"`
 }
 
 type Update struct {
@@ -264,14 +397,21 @@ type Update struct {
 	TotalEpisodes *int
 	TotalVolumes  *int
 	TotalChapters *int
+	Duration      *int
 	MediaType     string
 }
 
+// AnilistItem is a media found by FindAnilistItem or GetAnilistItemByID.
+// Score is the average score of the media, and TopReviews holds the three
+// most helpful of its ReviewCount reviews, each with the reviewer's score.
 type AnilistItem struct {
-	ID       int
-	URL      string
-	Score    int
-	Episodes *int
+	ID                int
+	URL               string
+	Score             int
+	Episodes          *int
+	NextAiringEpisode *AiringSchedule
+	ReviewCount       int
+	TopReviews        []Review
 }
 
 // NewAuthenticatedAPI creates and returns a new instance of AuthenticatedAPI
@@ -311,20 +451,13 @@ func GetAnilistItemByID(id int) (AnilistItem, error) {
 		"id": id,
 	}
 
-	media, err := fetchAnilistData(AnimeSearchQueryByID, variables)
+	media, err := fetchAnilistData(animeSearchQueryByID, variables)
 	if err != nil {
 		return AnilistItem{}, err
 	}
 
 	if media.ID != 0 {
-		url := fmt.Sprintf(AnimeURLFormat, media.ID)
-		score := media.AverageScore
-		return AnilistItem{
-			ID:       media.ID,
-			URL:      url,
-			Score:    score,
-			Episodes: media.Episodes,
-		}, nil
+		return newAnilistItem(media), nil
 	}
 
 	return AnilistItem{}, nil
@@ -349,14 +482,14 @@ func FindAnilistItem(title string, firstEpisodeDate *time.Time, offset int) (Ani
 
 	if firstEpisodeDate != nil {
 		season, seasonYear := computeSeason(*firstEpisodeDate, offset)
-		query = AnimeSearchQueryWithSeason
+		query = animeSearchQueryWithSeason
 		variables = map[string]interface{}{
 			"title":      title,
 			"season":     season,
 			"seasonYear": seasonYear,
 		}
 	} else {
-		query = AnimeSearchQuery
+		query = animeSearchQuery
 		variables = map[string]interface{}{
 			"title": title,
 		}
@@ -368,14 +501,7 @@ func FindAnilistItem(title string, firstEpisodeDate *time.Time, offset int) (Ani
 	}
 
 	if media.ID != 0 {
-		url := fmt.Sprintf(AnimeURLFormat, media.ID)
-		score := media.AverageScore
-		return AnilistItem{
-			ID:       media.ID,
-			URL:      url,
-			Score:    score,
-			Episodes: media.Episodes,
-		}, nil
+		return newAnilistItem(media), nil
 	} else if firstEpisodeDate != nil && isMonthInList(*firstEpisodeDate, BeginningSeasonMonths) && offset == 0 {
 		return FindAnilistItem(title, firstEpisodeDate, -1)
 	} else if firstEpisodeDate != nil && isMonthInList(*firstEpisodeDate, EndSeasonMonths) && offset == 0 {
@@ -477,32 +603,7 @@ func GetUpdates(username string, mediaType string, chunk *int, perChunk *int) ([
 
 	for _, mediaList := range mediaListCollection.Lists {
 		for _, entry := range mediaList.Entries {
-			update := Update{
-				UserName:    username,
-				MediaID:     entry.MediaID,
-				Title:       entry.Media.Title.English,
-				URL:         fmt.Sprintf(AnilistURLFormat, strings.ToLower(mediaType), entry.MediaID),
-				CoverURL:    entry.Media.CoverImage.ExtraLarge,
-				Status:      entry.Status,
-				UpdatedTime: entry.UpdatedAt,
-				Score:       entry.Score,
-				MediaType:   mediaType,
-			}
-
-			if update.Title == "" {
-				update.Title = entry.Media.Title.Romaji
-			}
-
-			if mediaType == MediaTypeAnime {
-				update.Progress = entry.Progress
-				update.TotalEpisodes = entry.Media.Episodes
-			} else if mediaType == MediaTypeManga {
-				update.Progress = entry.Progress
-				update.ProgressVol = entry.ProgressVolumes
-				update.TotalVolumes = entry.Media.Volumes
-				update.TotalChapters = entry.Media.Chapters
-			}
-
+			update := entry.ToUpdate(username, mediaType)
 			updates = append(updates, update)
 		}
 	}
@@ -531,7 +632,8 @@ func GetUpdates(username string, mediaType string, chunk *int, perChunk *int) ([
 // The method will return an error if the request to the API fails, which
 // could be due to a variety of reasons: network issues, invalid access token,
 // invalid mediaID, or API changes. Otherwise, it returns nil indicating that
-// the progress update was successful.
+// the progress update was successful. Cached responses holding the media are
+// removed from the cache, see SetCache.
 //
 // Usage:
 //
@@ -553,6 +655,7 @@ func (api *AuthenticatedAPI) UpdateProgress(mediaID int, progress int, status st
 	if err != nil {
 		return err
 	}
+	invalidateListEntry(mediaID)
 	return nil
 }
 
@@ -593,6 +696,43 @@ func GetProgress(userName string, mediaID int) (int, error) {
 	return progress, nil
 }
 
+// CollectPages calls fetch for every page, starting at page 1, until the
+// returned PageInfo reports that there is no next page, and returns all items
+// of all pages. It stops at the first error and returns it along with the
+// items collected so far.
+//
+// Usage:
+//
+//	activities, err := CollectPages(func(page int) ([]Activity, PageInfo, error) {
+//	    return GetActivities(ActivityFilter{UserID: 12345}, page, PerPage)
+//	})
+func CollectPages[T any](fetch func(page int) ([]T, PageInfo, error)) ([]T, error) {
+	var items []T
+	for page := 1; ; page++ {
+		pageItems, pageInfo, err := fetch(page)
+		if err != nil {
+			return items, err
+		}
+
+		items = append(items, pageItems...)
+		if !pageInfo.HasNextPage {
+			return items, nil
+		}
+	}
+}
+
+func newAnilistItem(media Media) AnilistItem {
+	return AnilistItem{
+		ID:                media.ID,
+		URL:               fmt.Sprintf(AnimeURLFormat, media.ID),
+		Score:             media.AverageScore,
+		Episodes:          media.Episodes,
+		NextAiringEpisode: media.NextAiringEpisode,
+		ReviewCount:       media.Reviews.count(),
+		TopReviews:        media.Reviews.nodes(),
+	}
+}
+
 func computeSeason(firstEpisodeDate time.Time, offset int) (string, int) {
 	seasonIndex := (int(firstEpisodeDate.Month())-1)/3 + offset
 	seasonYear := firstEpisodeDate.Year()
@@ -661,56 +801,11 @@ func isMonthInList(date time.Time, list []int) bool {
 }
 
 func sendRequest(url, query string, variables map[string]interface{}, accessToken string) (*Response, error) {
-	reqBody, err := json.Marshal(map[string]interface{}{
-		"query":     query,
-		"variables": variables,
-	})
+	body, _, err := doRequest(context.Background(), url, query, variables, accessToken)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(reqBody))
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	if accessToken != "" {
-		req.Header.Set("Authorization", "Bearer "+accessToken)
-	}
-
-	client := &http.Client{
-		Timeout: time.Second * Timeout,
-	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			panic(err)
-		}
-	}(resp.Body)
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	if (resp.StatusCode < http.StatusOK || resp.StatusCode > http.StatusIMUsed) && resp.StatusCode != http.StatusNotFound {
-		return nil, fmt.Errorf(
-			"request failed with status code %d\nX-RateLimit-Limit: %s\nX-RateLimit-Remaining: %s\nRetry-After: %s\nBody: %s",
-			resp.StatusCode,
-			resp.Header.Get("X-RateLimit-Limit"),
-			resp.Header.Get("X-RateLimit-Remaining"),
-			resp.Header.Get("Retry-After"),
-			string(body),
-		)
-	}
-
 	var result Response
 	err = json.Unmarshal(body, &result)
 	if err != nil {
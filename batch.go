@@ -0,0 +1,385 @@
+package anilistgo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxBatchSize is the number of lookups a Batch sends in one request. Larger
+// batches are sent in several requests.
+const MaxBatchSize = 25
+
+const batchMediaFragment = "batchMedia"
+
+// Future is the result of a lookup added to a Batch, available once the batch
+// was sent with Do.
+type Future[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+func newFuture[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+// Done returns a channel that is closed once the result is available.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Get waits for the result of the lookup and returns it. It returns the error
+// of ctx if ctx is done first, such as when the batch is never sent.
+func (f *Future[T]) Get(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+func (f *Future[T]) resolve(value T, err error) {
+	f.value = value
+	f.err = err
+	close(f.done)
+}
+
+// Batch collects lookups and sends them as a single GraphQL document, with
+// one aliased field per lookup. AniList rate limits requests rather than
+// fields, so resolving 30 titles with a Batch costs one request instead of 30.
+// Lookups can be added from several goroutines.
+type Batch struct {
+	mu          sync.Mutex
+	url         string
+	accessToken string
+	lookups     []*batchLookup
+}
+
+// batchLookup is a field of a batch document. field returns the field with
+// its arguments, where the variables are prefixed with prefix, and resolve
+// receives the data of the field, which is nil if it is null.
+type batchLookup struct {
+	field     func(prefix string) string
+	selection string
+	variables []batchVariable
+	resolve   func(data json.RawMessage, err error)
+}
+
+type batchVariable struct {
+	name     string
+	typeName string
+	value    interface{}
+}
+
+// NewBatch creates an empty Batch.
+//
+// Usage:
+//
+//	batch := NewBatch()
+//	frieren := batch.FindAnilistItem("Sousou no Frieren", nil)
+//	onePiece := batch.GetAnilistItemByID(21)
+//	if err := batch.Do(ctx); err != nil {
+//	    return err
+//	}
+//	item, err := frieren.Get(ctx)
+func NewBatch() *Batch {
+	return &Batch{url: BaseAPIURL}
+}
+
+// NewBatch creates an empty Batch that is sent as the authenticated user, so
+// that lookups can see private lists.
+func (api *AuthenticatedAPI) NewBatch() *Batch {
+	return &Batch{url: BaseAPIURL, accessToken: api.AccessToken}
+}
+
+// Len returns the number of lookups waiting to be sent.
+func (b *Batch) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.lookups)
+}
+
+// GetAnilistItemByID adds a lookup of a media by ID to the batch, resolved
+// like GetAnilistItemByID.
+func (b *Batch) GetAnilistItemByID(id int) *Future[AnilistItem] {
+	future := newFuture[AnilistItem]()
+	b.add(&batchLookup{
+		field: func(prefix string) string {
+			return fmt.Sprintf("Media (id: $%sid)", prefix)
+		},
+		selection: "{ ..." + batchMediaFragment + " }",
+		variables: []batchVariable{{name: "id", typeName: "Int", value: id}},
+		resolve:   resolveAnilistItem(future),
+	})
+	return future
+}
+
+// FindAnilistItem adds a search of an anime by title to the batch, resolved
+// like FindAnilistItem with an offset of 0. If no anime is found in the
+// season of firstEpisodeDate, the neighbouring season is searched by the
+// same call of Do.
+func (b *Batch) FindAnilistItem(title string, firstEpisodeDate *time.Time) *Future[AnilistItem] {
+	future := newFuture[AnilistItem]()
+	b.findAnilistItem(future, title, firstEpisodeDate, 0)
+	return future
+}
+
+// GetProgress adds a lookup of a user's progress on a media to the batch,
+// resolved like GetProgress.
+func (b *Batch) GetProgress(userName string, mediaID int) *Future[int] {
+	future := newFuture[int]()
+	b.add(&batchLookup{
+		field: func(prefix string) string {
+			return fmt.Sprintf("MediaList (userName: $%[1]suserName, mediaId: $%[1]smediaId)", prefix)
+		},
+		selection: "{ progress }",
+		variables: []batchVariable{
+			{name: "userName", typeName: "String", value: userName},
+			{name: "mediaId", typeName: "Int", value: mediaID},
+		},
+		resolve: func(data json.RawMessage, err error) {
+			var list MediaList
+			if err == nil && data != nil {
+				err = json.Unmarshal(data, &list)
+			}
+			future.resolve(list.Progress, err)
+		},
+	})
+	return future
+}
+
+// Do sends the lookups of the batch and resolves their futures, in requests
+// of at most MaxBatchSize lookups. A request that AniList rejects as too
+// complex is split in half and sent again. Lookups added while Do runs are
+// sent as well.
+//
+// Do returns the first error that failed a whole request, which is also
+// returned by the futures of its lookups. Errors of a single field are only
+// returned by the future of the lookup.
+func (b *Batch) Do(ctx context.Context) error {
+	var firstErr error
+	for {
+		b.mu.Lock()
+		lookups := b.lookups
+		b.lookups = nil
+		b.mu.Unlock()
+
+		if len(lookups) == 0 {
+			return firstErr
+		}
+		for start := 0; start < len(lookups); start += MaxBatchSize {
+			end := min(start+MaxBatchSize, len(lookups))
+			if err := b.send(ctx, lookups[start:end]); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+}
+
+func (b *Batch) add(lookup *batchLookup) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lookups = append(b.lookups, lookup)
+}
+
+func (b *Batch) findAnilistItem(future *Future[AnilistItem], title string, firstEpisodeDate *time.Time, offset int) {
+	lookup := &batchLookup{
+		field: func(prefix string) string {
+			return fmt.Sprintf("Media (type: ANIME, search: $%stitle)", prefix)
+		},
+		selection: "{ ..." + batchMediaFragment + " }",
+		variables: []batchVariable{{name: "title", typeName: "String", value: title}},
+	}
+	if firstEpisodeDate != nil {
+		season, seasonYear := computeSeason(*firstEpisodeDate, offset)
+		lookup.field = func(prefix string) string {
+			return fmt.Sprintf("Media (type: ANIME, search: $%[1]stitle, season: $%[1]sseason, seasonYear: $%[1]sseasonYear)", prefix)
+		}
+		lookup.variables = append(lookup.variables,
+			batchVariable{name: "season", typeName: "MediaSeason", value: season},
+			batchVariable{name: "seasonYear", typeName: "Int", value: seasonYear},
+		)
+	}
+
+	resolve := resolveAnilistItem(future)
+	lookup.resolve = func(data json.RawMessage, err error) {
+		if err == nil && data == nil && firstEpisodeDate != nil && offset == 0 {
+			if isMonthInList(*firstEpisodeDate, BeginningSeasonMonths) {
+				b.findAnilistItem(future, title, firstEpisodeDate, -1)
+				return
+			}
+			if isMonthInList(*firstEpisodeDate, EndSeasonMonths) {
+				b.findAnilistItem(future, title, firstEpisodeDate, 1)
+				return
+			}
+		}
+		resolve(data, err)
+	}
+	b.add(lookup)
+}
+
+func resolveAnilistItem(future *Future[AnilistItem]) func(json.RawMessage, error) {
+	return func(data json.RawMessage, err error) {
+		if err != nil || data == nil {
+			future.resolve(AnilistItem{}, err)
+			return
+		}
+
+		var media Media
+		if err := json.Unmarshal(data, &media); err != nil {
+			future.resolve(AnilistItem{}, err)
+			return
+		}
+		if media.ID == 0 {
+			future.resolve(AnilistItem{}, nil)
+			return
+		}
+		future.resolve(newAnilistItem(media), nil)
+	}
+}
+
+// send sends lookups in one request and resolves them, splitting them in
+// half if the request is too complex.
+func (b *Batch) send(ctx context.Context, lookups []*batchLookup) error {
+	query, variables := batchDocument(lookups)
+	body, statusCode, err := doRequest(ctx, b.url, query, variables, b.accessToken)
+	if err == nil {
+		var envelope struct {
+			Data   map[string]json.RawMessage `json:"data"`
+			Errors []GraphQLError             `json:"errors"`
+		}
+		if err = json.Unmarshal(body, &envelope); err == nil {
+			err = resolveBatch(lookups, envelope.Data, envelope.Errors, statusCode, string(body))
+			if err == nil {
+				return nil
+			}
+		}
+	}
+
+	if isComplexityError(err) && len(lookups) > 1 {
+		half := len(lookups) / 2
+		firstErr := b.send(ctx, lookups[:half])
+		if err := b.send(ctx, lookups[half:]); firstErr == nil {
+			firstErr = err
+		}
+		return firstErr
+	}
+	for _, lookup := range lookups {
+		lookup.resolve(nil, err)
+	}
+	return err
+}
+
+// resolveBatch resolves every lookup with the data of its alias. It resolves
+// nothing and returns an error if there is no data at all, such as when the
+// document was rejected. Fields that were not found resolve to null data,
+// like the single lookups do.
+//
+// AniList reports errors without a path, so an error is matched to its alias
+// by path if it has one, and otherwise applies to every alias whose data is
+// null. Not found errors are not errors of the lookups.
+func resolveBatch(lookups []*batchLookup, data map[string]json.RawMessage, graphQLErrors []GraphQLError, statusCode int, body string) error {
+	if data == nil {
+		if len(graphQLErrors) == 0 {
+			return fmt.Errorf("batch response without data")
+		}
+		return &APIError{StatusCode: statusCode, Errors: graphQLErrors, Body: body}
+	}
+
+	var unmatched []GraphQLError
+	fieldErrors := make(map[string][]GraphQLError)
+	for _, graphQLError := range graphQLErrors {
+		if graphQLError.Status == http.StatusNotFound {
+			continue
+		}
+		if alias, ok := graphQLError.alias(); ok {
+			fieldErrors[alias] = append(fieldErrors[alias], graphQLError)
+		} else {
+			unmatched = append(unmatched, graphQLError)
+		}
+	}
+
+	for i, lookup := range lookups {
+		alias := batchAlias(i)
+		fieldData := data[alias]
+		if string(fieldData) == "null" {
+			fieldData = nil
+		}
+
+		errs := fieldErrors[alias]
+		if fieldData == nil {
+			errs = append(errs, unmatched...)
+		}
+		if len(errs) > 0 {
+			lookup.resolve(nil, &APIError{StatusCode: statusCode, Errors: errs, Body: body})
+			continue
+		}
+		lookup.resolve(fieldData, nil)
+	}
+	return nil
+}
+
+// batchDocument builds the query of lookups, where the field of the lookup
+// at index i is aliased as b<i> and its variables are prefixed with b<i>_.
+func batchDocument(lookups []*batchLookup) (string, map[string]interface{}) {
+	var definitions []string
+	var fields strings.Builder
+	variables := make(map[string]interface{})
+	usesMedia := false
+
+	for i, lookup := range lookups {
+		alias := batchAlias(i)
+		prefix := alias + "_"
+		for _, variable := range lookup.variables {
+			definitions = append(definitions, fmt.Sprintf("$%s%s: %s", prefix, variable.name, variable.typeName))
+			variables[prefix+variable.name] = variable.value
+		}
+		fmt.Fprintf(&fields, " %s: %s %s", alias, lookup.field(prefix), lookup.selection)
+		usesMedia = usesMedia || strings.Contains(lookup.selection, "..."+batchMediaFragment)
+	}
+
+	query := "query"
+	if len(definitions) > 0 {
+		query += " (" + strings.Join(definitions, ", ") + ")"
+	}
+	query += " {" + fields.String() + " }"
+	if usesMedia {
+		query += " fragment " + batchMediaFragment + " on Media " + SelectionSet(Media{})
+	}
+	return query, variables
+}
+
+func batchAlias(index int) string {
+	return fmt.Sprintf("b%d", index)
+}
+
+func (e GraphQLError) alias() (string, bool) {
+	if len(e.Path) == 0 {
+		return "", false
+	}
+	alias, ok := e.Path[0].(string)
+	return alias, ok
+}
+
+// isComplexityError reports whether err is AniList rejecting a query that
+// exceeds the maximum query complexity or depth.
+func isComplexityError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, graphQLError := range apiErr.Errors {
+		if strings.Contains(strings.ToLower(graphQLError.Message), "complexity") {
+			return true
+		}
+	}
+	return false
+}
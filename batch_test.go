@@ -0,0 +1,217 @@
+package anilistgo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type batchRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+func newBatchServer(t *testing.T, handle func(request batchRequest) (int, string)) (*httptest.Server, *[]batchRequest) {
+	var requests []batchRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request batchRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("invalid request: %v", err)
+		}
+		requests = append(requests, request)
+
+		statusCode, body := handle(request)
+		w.WriteHeader(statusCode)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestBatchDocument(t *testing.T) {
+	batch := NewBatch()
+	batch.GetAnilistItemByID(21)
+	batch.GetProgress("Ithilias", 5114)
+
+	query, variables := batchDocument(batch.lookups)
+
+	expected := "query ($b0_id: Int, $b1_userName: String, $b1_mediaId: Int) { b0: Media (id: $b0_id) { ...batchMedia } b1: MediaList (userName: $b1_userName, mediaId: $b1_mediaId) { progress } } fragment batchMedia on Media " + SelectionSet(Media{})
+	if query != expected {
+		t.Errorf("expected query %q but got %q", expected, query)
+	}
+	if variables["b0_id"] != 21 || variables["b1_userName"] != "Ithilias" || variables["b1_mediaId"] != 5114 {
+		t.Errorf("unexpected variables: %v", variables)
+	}
+
+	batch = NewBatch()
+	batch.GetProgress("Ithilias", 5114)
+	if query, _ := batchDocument(batch.lookups); strings.Contains(query, "fragment") {
+		t.Errorf("expected no media fragment in %q", query)
+	}
+}
+
+func TestBatchDo(t *testing.T) {
+	server, requests := newBatchServer(t, func(request batchRequest) (int, string) {
+		// AniList reports a field that was not found without a path.
+		return http.StatusNotFound, `{
+			"data": {"b0": {"id": 21, "averageScore": 88}, "b1": null, "b2": {"progress": 12}, "b3": null},
+			"errors": [
+				{"message": "Not Found.", "status": 404, "locations": [{"line": 1, "column": 120}]},
+				{"message": "Private list", "status": 403, "path": ["b3"]}
+			]
+		}`
+	})
+
+	batch := NewBatch()
+	batch.url = server.URL
+	found := batch.GetAnilistItemByID(21)
+	missing := batch.GetAnilistItemByID(-1)
+	progress := batch.GetProgress("Ithilias", 21)
+	private := batch.GetProgress("Someone", 21)
+
+	if err := batch.Do(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(*requests) != 1 {
+		t.Errorf("expected a single request but got %d", len(*requests))
+	}
+	if batch.Len() != 0 {
+		t.Errorf("expected the batch to be empty after Do but got %d lookups", batch.Len())
+	}
+
+	ctx := context.Background()
+	if item, err := found.Get(ctx); err != nil || item.ID != 21 || item.Score != 88 || item.URL != fmt.Sprintf(AnimeURLFormat, 21) {
+		t.Errorf("unexpected item %+v, %v", item, err)
+	}
+	if item, err := missing.Get(ctx); err != nil || item.ID != 0 {
+		t.Errorf("expected an empty item for a missing media but got %+v, %v", item, err)
+	}
+	if value, err := progress.Get(ctx); err != nil || value != 12 {
+		t.Errorf("expected a progress of 12 but got %d, %v", value, err)
+	}
+	var apiErr *APIError
+	if _, err := private.Get(ctx); !errors.As(err, &apiErr) || apiErr.Errors[0].Message != "Private list" {
+		t.Errorf("expected the field error of the private list but got %v", err)
+	}
+}
+
+func TestBatchFindAnilistItemSearchesNeighbouringSeason(t *testing.T) {
+	server, requests := newBatchServer(t, func(request batchRequest) (int, string) {
+		if request.Variables["b0_season"] == "WINTER" {
+			return http.StatusNotFound, `{"data": {"b0": null}, "errors": [{"message": "Not Found.", "status": 404, "locations": [{"line": 1, "column": 20}]}]}`
+		}
+		return http.StatusOK, `{"data": {"b0": {"id": 154587}}}`
+	})
+
+	batch := NewBatch()
+	batch.url = server.URL
+	firstEpisodeDate := time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)
+	future := batch.FindAnilistItem("Sousou no Frieren", &firstEpisodeDate)
+
+	if err := batch.Do(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	item, err := future.Get(context.Background())
+	if err != nil || item.ID != 154587 {
+		t.Errorf("expected the item of the previous season but got %+v, %v", item, err)
+	}
+	if len(*requests) != 2 || (*requests)[1].Variables["b0_season"] != "FALL" {
+		t.Errorf("expected a second request for the fall season but got %+v", *requests)
+	}
+}
+
+func TestBatchSplitsComplexRequests(t *testing.T) {
+	server, requests := newBatchServer(t, func(request batchRequest) (int, string) {
+		if len(request.Variables) > 2 {
+			return http.StatusBadRequest, `{"data": null, "errors": [{"message": "Max query complexity should be 500 but got 510.", "status": 400}]}`
+		}
+		var data []string
+		for name, id := range request.Variables {
+			data = append(data, fmt.Sprintf(`"%s": {"id": %v}`, strings.TrimSuffix(name, "_id"), id))
+		}
+		return http.StatusOK, `{"data": {` + strings.Join(data, ", ") + `}}`
+	})
+
+	batch := NewBatch()
+	batch.url = server.URL
+	var futures []*Future[AnilistItem]
+	for id := 1; id <= 5; id++ {
+		futures = append(futures, batch.GetAnilistItemByID(id))
+	}
+
+	if err := batch.Do(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	for i, future := range futures {
+		if item, err := future.Get(context.Background()); err != nil || item.ID != i+1 {
+			t.Errorf("expected item %d but got %+v, %v", i+1, item, err)
+		}
+	}
+	// 5 lookups, then 2 and 3, then 1 and 2 of the latter.
+	if len(*requests) != 5 {
+		t.Errorf("expected 5 requests but got %d", len(*requests))
+	}
+}
+
+func TestBatchFailsLookupsOfFailedRequests(t *testing.T) {
+	server, _ := newBatchServer(t, func(request batchRequest) (int, string) {
+		return http.StatusBadRequest, `{"data": null, "errors": [{"message": "Validation error", "status": 400}]}`
+	})
+
+	batch := NewBatch()
+	batch.url = server.URL
+	first := batch.GetAnilistItemByID(1)
+	second := batch.GetProgress("Ithilias", 1)
+
+	err := batch.Do(context.Background())
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if _, firstErr := first.Get(context.Background()); firstErr != err {
+		t.Errorf("expected the request error but got %v", firstErr)
+	}
+	if _, secondErr := second.Get(context.Background()); secondErr != err {
+		t.Errorf("expected the request error but got %v", secondErr)
+	}
+}
+
+func TestFutureGetHonorsContext(t *testing.T) {
+	future := NewBatch().GetAnilistItemByID(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := future.Get(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled but got %v", err)
+	}
+}
+
+func TestBatchAppliesErrorsWithoutPathToNullFields(t *testing.T) {
+	server, _ := newBatchServer(t, func(request batchRequest) (int, string) {
+		return http.StatusOK, `{
+			"data": {"b0": {"id": 1}, "b1": null},
+			"errors": [{"message": "Internal Server Error", "status": 500, "locations": [{"line": 1, "column": 60}]}]
+		}`
+	})
+
+	batch := NewBatch()
+	batch.url = server.URL
+	found := batch.GetAnilistItemByID(1)
+	failed := batch.GetAnilistItemByID(2)
+
+	if err := batch.Do(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if item, err := found.Get(context.Background()); err != nil || item.ID != 1 {
+		t.Errorf("expected the field with data to resolve but got %+v, %v", item, err)
+	}
+	var apiErr *APIError
+	if _, err := failed.Get(context.Background()); !errors.As(err, &apiErr) || apiErr.Errors[0].Status != 500 {
+		t.Errorf("expected the error to apply to the null field but got %v", err)
+	}
+}
@@ -0,0 +1,651 @@
+package anilistgo
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultMediaTTL = 24 * time.Hour
+	DefaultListTTL  = 5 * time.Minute
+)
+
+// Cache stores responses of the AniList API, see SetCache. Implementations
+// must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored under key, unless it expired.
+	Get(key string) ([]byte, bool)
+	// Set stores value under key for the duration of ttl.
+	Set(key string, value []byte, ttl time.Duration) error
+	// Delete removes the value stored under key, if any.
+	Delete(key string) error
+}
+
+// CacheTTL holds how long responses are cached by type of operation. Media
+// holds queries of media only, List queries of lists or list entries, and
+// Other every other query. A zero duration disables caching of the type.
+type CacheTTL struct {
+	Media time.Duration
+	List  time.Duration
+	Other time.Duration
+}
+
+// DefaultCacheTTL caches media for a day and lists for five minutes, since
+// list entries change whenever their owner watches an episode. A response
+// holding the next airing episode of a media is only cached until the
+// episode airs, and its timeUntilAiring is recomputed whenever it is read.
+var DefaultCacheTTL = CacheTTL{
+	Media: DefaultMediaTTL,
+	List:  DefaultListTTL,
+}
+
+var responseCache struct {
+	mu    sync.Mutex
+	cache Cache
+	ttl   CacheTTL
+	// media maps media IDs to the keys of the responses holding them, and
+	// lists holds the keys of the responses of lists, with the time they
+	// expire. The index is kept outside of the cache, which may evict it.
+	media map[int]map[string]time.Time
+	lists map[string]time.Time
+}
+
+// SetCache sets the cache of the responses of AniList, shared by all calls of
+// the package, or disables caching if cache is nil. Requests sent as an
+// authenticated user and mutations are never cached.
+//
+// Responses are keyed by a hash of the query, with its whitespace collapsed,
+// and of the variables. Responses of media and lists are indexed in memory by
+// the media IDs they hold, so that UpdateProgress, SaveMediaListEntry and
+// InvalidateMedia remove them from the cache, and mutations of lists remove
+// every cached list. Responses cached by an earlier process, such as in a
+// DiskCache, are not indexed and are only removed once they expire.
+//
+// Usage:
+//
+//	cache, err := NewDiskCache(filepath.Join(os.TempDir(), "anilistgo"))
+//	if err != nil {
+//	    return err
+//	}
+//	SetCache(cache, DefaultCacheTTL)
+func SetCache(cache Cache, ttl CacheTTL) {
+	responseCache.mu.Lock()
+	defer responseCache.mu.Unlock()
+	responseCache.cache = cache
+	responseCache.ttl = ttl
+	responseCache.media = make(map[int]map[string]time.Time)
+	responseCache.lists = make(map[string]time.Time)
+}
+
+// InvalidateMedia removes the cached responses holding the media with the
+// given ID, such as its details or list entries of it.
+func InvalidateMedia(mediaID int) error {
+	responseCache.mu.Lock()
+	defer responseCache.mu.Unlock()
+	if responseCache.cache == nil {
+		return nil
+	}
+	return invalidateMedia(responseCache.cache, mediaID)
+}
+
+// InvalidateLists removes the cached responses of lists and list entries,
+// such as after the list of a user changed.
+func InvalidateLists() error {
+	responseCache.mu.Lock()
+	defer responseCache.mu.Unlock()
+	if responseCache.cache == nil {
+		return nil
+	}
+
+	for key := range responseCache.lists {
+		if err := responseCache.cache.Delete(key); err != nil {
+			return err
+		}
+		delete(responseCache.lists, key)
+	}
+	return nil
+}
+
+func invalidateMedia(cache Cache, mediaID int) error {
+	for key := range responseCache.media[mediaID] {
+		if err := cache.Delete(key); err != nil {
+			return err
+		}
+		delete(responseCache.media[mediaID], key)
+	}
+	delete(responseCache.media, mediaID)
+	return nil
+}
+
+// invalidateListEntry removes the cached responses a mutation of the list
+// entry of a media made stale. The mutation already succeeded, so errors of
+// the cache are ignored and the responses expire with their TTL.
+func invalidateListEntry(mediaID int) {
+	InvalidateMedia(mediaID)
+	InvalidateLists()
+}
+
+// cachedResponse returns the cache key and TTL of a request, and its cached
+// body if there is one. The key is empty if the request must not be cached.
+func cachedResponse(query string, variables any, accessToken string) (string, time.Duration, []byte, bool) {
+	responseCache.mu.Lock()
+	defer responseCache.mu.Unlock()
+	if responseCache.cache == nil || accessToken != "" {
+		return "", 0, nil, false
+	}
+
+	ttl := operationTTL(query, responseCache.ttl)
+	if ttl <= 0 {
+		return "", 0, nil, false
+	}
+	key, err := cacheKey(query, variables)
+	if err != nil {
+		return "", 0, nil, false
+	}
+
+	body, ok := responseCache.cache.Get(key)
+	if ok {
+		body = refreshAiring(body, time.Now())
+	}
+	return key, ttl, body, ok
+}
+
+// storeResponse caches a successful response, and adds its key to the index
+// of every media it holds and, for lists, to the index of lists. Responses
+// with errors are not cached.
+func storeResponse(key string, ttl time.Duration, query string, variables any, body []byte) {
+	var envelope struct {
+		Data   map[string]json.RawMessage `json:"data"`
+		Errors []json.RawMessage          `json:"errors"`
+	}
+	if json.Unmarshal(body, &envelope) != nil || len(envelope.Errors) > 0 || envelope.Data == nil {
+		return
+	}
+
+	now := time.Now()
+	ttl = airingTTL(envelope.Data, ttl, now)
+	if ttl <= 0 {
+		return
+	}
+
+	responseCache.mu.Lock()
+	defer responseCache.mu.Unlock()
+	cache := responseCache.cache
+	if cache == nil || cache.Set(key, body, ttl) != nil {
+		return
+	}
+
+	expires := now.Add(ttl)
+	for mediaID := range responseMediaIDs(query, variables, envelope.Data) {
+		keys := responseCache.media[mediaID]
+		if keys == nil {
+			keys = make(map[string]time.Time)
+			responseCache.media[mediaID] = keys
+		}
+		pruneExpired(keys, now)
+		keys[key] = expires
+	}
+	if _, fields := rootFields(query); hasListField(fields) {
+		pruneExpired(responseCache.lists, now)
+		responseCache.lists[key] = expires
+	}
+}
+
+// pruneExpired removes the keys of the index that expired, whose responses
+// are gone from the cache.
+func pruneExpired(keys map[string]time.Time, now time.Time) {
+	for key, expires := range keys {
+		if !now.Before(expires) {
+			delete(keys, key)
+		}
+	}
+}
+
+// cacheKey hashes the query, with its whitespace collapsed, and the
+// variables, encoded as JSON with sorted map keys.
+func cacheKey(query string, variables any) (string, error) {
+	encoded, err := json.Marshal(variables)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(strings.Join(strings.Fields(query), " ")))
+	hash.Write([]byte{0})
+	hash.Write(encoded)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// operationTTL returns the TTL of the shortest lived root field of a query,
+// or zero for mutations and documents it cannot read.
+func operationTTL(query string, ttl CacheTTL) time.Duration {
+	kind, fields := rootFields(query)
+	if kind != "query" || len(fields) == 0 {
+		return 0
+	}
+
+	shortest := time.Duration(-1)
+	for _, field := range fields {
+		fieldTTL := ttl.Other
+		switch {
+		case field.name == "Media":
+			fieldTTL = ttl.Media
+		case isListField(field):
+			fieldTTL = ttl.List
+		}
+		if shortest < 0 || fieldTTL < shortest {
+			shortest = fieldTTL
+		}
+	}
+	return shortest
+}
+
+// airingTTL shortens ttl to the time until the earliest next airing episode
+// of the data, after which the episode is no longer the next one. It returns
+// zero if an episode already aired.
+func airingTTL(data map[string]json.RawMessage, ttl time.Duration, now time.Time) time.Duration {
+	for _, value := range data {
+		if !strings.Contains(string(value), `"nextAiringEpisode"`) {
+			continue
+		}
+		var decoded interface{}
+		if decodeNumbers(value, &decoded) != nil {
+			continue
+		}
+		walkJSON(decoded, func(key string, object map[string]interface{}) {
+			if key != "nextAiringEpisode" {
+				return
+			}
+			if airingAt, ok := jsonInt(object["airingAt"]); ok {
+				ttl = min(ttl, time.Unix(airingAt, 0).Sub(now))
+			}
+		})
+	}
+	return ttl
+}
+
+// refreshAiring sets every timeUntilAiring of a cached body to the time left
+// until its airingAt, so that it does not count down from when the body was
+// cached.
+func refreshAiring(body []byte, now time.Time) []byte {
+	if !strings.Contains(string(body), `"timeUntilAiring"`) {
+		return body
+	}
+	var decoded interface{}
+	if decodeNumbers(body, &decoded) != nil {
+		return body
+	}
+
+	walkJSON(decoded, func(key string, object map[string]interface{}) {
+		airingAt, ok := jsonInt(object["airingAt"])
+		if _, has := object["timeUntilAiring"]; ok && has {
+			object["timeUntilAiring"] = airingAt - now.Unix()
+		}
+	})
+
+	refreshed, err := json.Marshal(decoded)
+	if err != nil {
+		return body
+	}
+	return refreshed
+}
+
+// walkJSON calls fn for every object of a decoded JSON value, with the key it
+// is held under, which is empty for objects of arrays and the root.
+func walkJSON(value interface{}, fn func(key string, object map[string]interface{})) {
+	var walk func(key string, value interface{})
+	walk = func(key string, value interface{}) {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			fn(key, value)
+			for childKey, child := range value {
+				walk(childKey, child)
+			}
+		case []interface{}:
+			for _, child := range value {
+				walk("", child)
+			}
+		}
+	}
+	walk("", value)
+}
+
+func decodeNumbers(data []byte, value *interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(value)
+}
+
+func jsonInt(value interface{}) (int64, bool) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	n, err := number.Int64()
+	return n, err == nil
+}
+
+func isListField(field cacheRootField) bool {
+	return field.name == "MediaList" || field.name == "MediaListCollection"
+}
+
+func hasListField(fields []cacheRootField) bool {
+	for _, field := range fields {
+		if isListField(field) {
+			return true
+		}
+	}
+	return false
+}
+
+// responseMediaIDs returns the IDs of the media a response holds: the mediaId
+// variables, the IDs of root Media fields, and every mediaId of the data.
+func responseMediaIDs(query string, variables any, data map[string]json.RawMessage) map[int]bool {
+	ids := make(map[int]bool)
+
+	var vars map[string]interface{}
+	if encoded, err := json.Marshal(variables); err == nil {
+		json.Unmarshal(encoded, &vars)
+	}
+	for name, value := range vars {
+		if id, ok := value.(float64); ok && (name == "mediaId" || strings.HasSuffix(name, "_mediaId")) {
+			ids[int(id)] = true
+		}
+	}
+
+	_, fields := rootFields(query)
+	for _, field := range fields {
+		if field.name != "Media" {
+			continue
+		}
+		var media struct {
+			ID int `json:"id"`
+		}
+		if json.Unmarshal(data[field.key], &media) == nil && media.ID != 0 {
+			ids[media.ID] = true
+		}
+	}
+
+	for _, value := range data {
+		var decoded interface{}
+		if json.Unmarshal(value, &decoded) == nil {
+			collectMediaIDs(decoded, ids)
+		}
+	}
+	return ids
+}
+
+func collectMediaIDs(value interface{}, ids map[int]bool) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if id, ok := child.(float64); ok && key == "mediaId" {
+				ids[int(id)] = true
+				continue
+			}
+			collectMediaIDs(child, ids)
+		}
+	case []interface{}:
+		for _, child := range value {
+			collectMediaIDs(child, ids)
+		}
+	}
+}
+
+type cacheRootField struct {
+	key  string
+	name string
+}
+
+// rootFields returns the kind of operation of a GraphQL document, "query" for
+// the shorthand "{ ... }", and the fields it selects on the root type, with
+// their alias as key if they have one. Fragments after the operation are
+// ignored.
+func rootFields(document string) (string, []cacheRootField) {
+	document = strings.TrimSpace(document)
+	kind := operationKind(document)
+	if kind == "" {
+		return "", nil
+	}
+
+	start := strings.Index(document, "{")
+	if start < 0 {
+		return kind, nil
+	}
+	if open := strings.Index(document, "("); open >= 0 && open < start {
+		end := skipGroup(document, open, '(', ')')
+		start = strings.Index(document[end:], "{")
+		if start < 0 {
+			return kind, nil
+		}
+		start += end
+	}
+
+	var fields []cacheRootField
+	var alias string
+	for i := start + 1; i < len(document); {
+		c := document[i]
+		switch {
+		case c == '}':
+			return kind, fields
+		case c == '(':
+			i = skipGroup(document, i, '(', ')')
+		case c == '{':
+			i = skipGroup(document, i, '{', '}')
+		case c == '@':
+			i++
+			for i < len(document) && isNameByte(document[i]) {
+				i++
+			}
+		case isNameByte(c):
+			end := i
+			for end < len(document) && isNameByte(document[end]) {
+				end++
+			}
+			name := document[i:end]
+			i = end
+			for i < len(document) && strings.IndexByte(" \t\r\n,", document[i]) >= 0 {
+				i++
+			}
+			if i < len(document) && document[i] == ':' {
+				alias = name
+				i++
+				continue
+			}
+			key := name
+			if alias != "" {
+				key, alias = alias, ""
+			}
+			fields = append(fields, cacheRootField{key: key, name: name})
+		default:
+			i++
+		}
+	}
+	return kind, fields
+}
+
+// skipGroup returns the index after the group opened at start, skipping
+// strings and nested groups.
+func skipGroup(document string, start int, open byte, close byte) int {
+	depth := 0
+	for i := start; i < len(document); i++ {
+		switch document[i] {
+		case '"':
+			for i++; i < len(document) && document[i] != '"'; i++ {
+				if document[i] == '\\' {
+					i++
+				}
+			}
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(document)
+}
+
+func isNameByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// MemoryCache is a Cache holding up to a number of entries in memory, which
+// evicts the least recently used entry when it is full.
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+	now      func() time.Time
+}
+
+type memoryEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache creates a MemoryCache holding up to capacity entries.
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*memoryEntry)
+	if !c.now().Before(entry.expires) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := c.now().Add(ttl)
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*memoryEntry)
+		entry.value = value
+		entry.expires = expires
+		c.order.MoveToFront(element)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&memoryEntry{key: key, value: value, expires: expires})
+	for c.capacity > 0 && c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryEntry).key)
+	}
+	return nil
+}
+
+func (c *MemoryCache) Delete(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.order.Remove(element)
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+// DiskCache is a Cache storing every entry in a file of a directory, so that
+// it is kept across runs. Expired files are removed when they are read.
+type DiskCache struct {
+	dir string
+	now func() time.Time
+}
+
+type diskEntry struct {
+	Expires time.Time `json:"expires"`
+	Value   []byte    `json:"value"`
+}
+
+// NewDiskCache creates a DiskCache storing its entries in dir, which is
+// created if it does not exist.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir, now: time.Now}, nil
+}
+
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry diskEntry
+	if err := json.Unmarshal(data, &entry); err != nil || !c.now().Before(entry.Expires) {
+		os.Remove(c.path(key))
+		return nil, false
+	}
+	return entry.Value, true
+}
+
+func (c *DiskCache) Set(key string, value []byte, ttl time.Duration) error {
+	data, err := json.Marshal(diskEntry{Expires: c.now().Add(ttl), Value: value})
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(c.dir, "*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	return os.Rename(file.Name(), c.path(key))
+}
+
+func (c *DiskCache) Delete(key string) error {
+	err := os.Remove(c.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path names the file of a key after its hash, since keys are not valid file names
+// everywhere.
+func (c *DiskCache) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(hash[:]))
+}
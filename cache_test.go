@@ -0,0 +1,302 @@
+package anilistgo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewMemoryCache(2)
+	cache.Set("a", []byte("1"), time.Hour)
+	cache.Set("b", []byte("2"), time.Hour)
+	cache.Get("a")
+	cache.Set("c", []byte("3"), time.Hour)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected the least recently used entry to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("expected entry %s to be kept", key)
+		}
+	}
+}
+
+func TestMemoryCacheExpiresEntries(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewMemoryCache(10)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("a", []byte("1"), time.Minute)
+	if value, ok := cache.Get("a"); !ok || string(value) != "1" {
+		t.Errorf("expected the entry before it expires but got %q, %v", value, ok)
+	}
+	now = now.Add(time.Minute)
+	if _, ok := cache.Get("a"); ok {
+		t.Error("expected the entry to expire")
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	cache, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.now = func() time.Time { return now }
+
+	if err := cache.Set("media:21", []byte(`["key"]`), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if value, ok := cache.Get("media:21"); !ok || string(value) != `["key"]` {
+		t.Errorf("expected the stored value but got %q, %v", value, ok)
+	}
+
+	if err := cache.Delete("media:21"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get("media:21"); ok {
+		t.Error("expected the entry to be deleted")
+	}
+	if err := cache.Delete("media:21"); err != nil {
+		t.Errorf("expected no error deleting a missing entry but got %v", err)
+	}
+
+	cache.Set("a", []byte("1"), time.Minute)
+	now = now.Add(time.Hour)
+	if _, ok := cache.Get("a"); ok {
+		t.Error("expected the entry to expire")
+	}
+}
+
+func TestCacheKeyNormalizesQuery(t *testing.T) {
+	first, err := cacheKey("query ($id: Int) {\n  Media (id: $id) { id }\n}", map[string]interface{}{"id": 21, "type": "ANIME"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := cacheKey("  query ($id: Int) { Media (id: $id) { id } }", map[string]interface{}{"type": "ANIME", "id": 21})
+	third, _ := cacheKey("query ($id: Int) { Media (id: $id) { id } }", map[string]interface{}{"id": 1})
+
+	if first != second {
+		t.Error("expected the same key for queries differing in whitespace")
+	}
+	if first == third {
+		t.Error("expected different keys for different variables")
+	}
+}
+
+func TestOperationTTL(t *testing.T) {
+	ttl := CacheTTL{Media: time.Hour, List: time.Minute}
+	tests := map[string]time.Duration{
+		animeSearchQueryByID:      time.Hour,
+		animeSearchQuery:          time.Hour,
+		ProgressQuery:             time.Minute,
+		UpdatesQuery:              time.Minute,
+		UserQuery:                 0,
+		UpdateProgressQuery:       0,
+		"{ Media(id: 1) { id } }": time.Hour,
+		"{ Media(id: 1) { id nextAiringEpisode { airingAt } } }":                       time.Hour,
+		`query { a: Media(search: "}") { id } b: MediaList(mediaId: 1) { progress } }`: time.Minute,
+	}
+	for query, expected := range tests {
+		if result := operationTTL(query, ttl); result != expected {
+			t.Errorf("expected a TTL of %s but got %s for %q", expected, result, query)
+		}
+	}
+}
+
+func TestDoRequestCachesResponses(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"data": {"Media": {"id": 21, "averageScore": 88}}}`))
+	}))
+	defer server.Close()
+
+	SetCache(NewMemoryCache(100), DefaultCacheTTL)
+	defer SetCache(nil, CacheTTL{})
+
+	ctx := context.Background()
+	variables := map[string]interface{}{"id": 21}
+	for i := 0; i < 2; i++ {
+		if _, _, err := doRequest(ctx, server.URL, animeSearchQueryByID, variables, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the second request to be cached but got %d requests", requests)
+	}
+
+	if _, _, err := doRequest(ctx, server.URL, animeSearchQueryByID, variables, "token"); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("expected authenticated requests to skip the cache but got %d requests", requests)
+	}
+
+	if err := InvalidateMedia(21); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := doRequest(ctx, server.URL, animeSearchQueryByID, variables, ""); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 3 {
+		t.Errorf("expected the invalidated media to be fetched again but got %d requests", requests)
+	}
+}
+
+func TestDefaultCacheTTLCachesMediaQueries(t *testing.T) {
+	for _, query := range []string{animeSearchQueryByID, animeSearchQuery, animeSearchQueryWithSeason} {
+		if result := operationTTL(query, DefaultCacheTTL); result != DefaultMediaTTL {
+			t.Errorf("expected the media TTL but got %s for %q", result, query)
+		}
+	}
+}
+
+func TestAiringTTL(t *testing.T) {
+	now := time.Unix(1000, 0)
+	data := map[string]json.RawMessage{
+		"Media": json.RawMessage(`{"id": 1, "nextAiringEpisode": {"airingAt": 4600, "timeUntilAiring": 3600}}`),
+	}
+	if ttl := airingTTL(data, 24*time.Hour, now); ttl != time.Hour {
+		t.Errorf("expected the TTL to end when the episode airs but got %s", ttl)
+	}
+	if ttl := airingTTL(data, time.Minute, now); ttl != time.Minute {
+		t.Errorf("expected a shorter TTL to be kept but got %s", ttl)
+	}
+	if ttl := airingTTL(data, time.Hour, time.Unix(5000, 0)); ttl > 0 {
+		t.Errorf("expected no TTL for an episode that aired but got %s", ttl)
+	}
+}
+
+func TestRefreshAiring(t *testing.T) {
+	body := []byte(`{"data": {"Media": {"id": 154587, "nextAiringEpisode": {"airingAt": 4600, "timeUntilAiring": 3600, "episode": 5}}}}`)
+
+	var refreshed struct {
+		Data struct {
+			Media Media `json:"Media"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(refreshAiring(body, time.Unix(4000, 0)), &refreshed); err != nil {
+		t.Fatal(err)
+	}
+	media := refreshed.Data.Media
+	if media.ID != 154587 || media.NextAiringEpisode == nil || media.NextAiringEpisode.TimeUntilAiring != 600 || media.NextAiringEpisode.Episode != 5 {
+		t.Errorf("expected the time until airing to be recomputed but got %+v", media.NextAiringEpisode)
+	}
+}
+
+func TestCacheIndexIsNotEvicted(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"data": {"Media": {"id": 21}}}`))
+	}))
+	defer server.Close()
+
+	// A cache of a single entry would evict the response for an index
+	// stored along with it.
+	SetCache(NewMemoryCache(1), DefaultCacheTTL)
+	defer SetCache(nil, CacheTTL{})
+
+	ctx := context.Background()
+	query := "query ($id: Int) { Media (id: $id) { id } }"
+	variables := map[string]interface{}{"id": 21}
+	for i := 0; i < 2; i++ {
+		if _, _, err := doRequest(ctx, server.URL, query, variables, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the second request to be cached but got %d requests", requests)
+	}
+
+	if err := InvalidateMedia(21); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := doRequest(ctx, server.URL, query, variables, ""); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("expected the invalidated media to be fetched again but got %d requests", requests)
+	}
+}
+
+func TestInvalidateListEntryInvalidatesLists(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"data": {"MediaListCollection": {"lists": [{"entries": [{"mediaId": 21}]}]}}}`))
+	}))
+	defer server.Close()
+
+	SetCache(NewMemoryCache(100), DefaultCacheTTL)
+	defer SetCache(nil, CacheTTL{})
+
+	ctx := context.Background()
+	variables := map[string]interface{}{"userName": "Ithilias", "type": "ANIME"}
+	fetch := func() {
+		t.Helper()
+		if _, _, err := doRequest(ctx, server.URL, MediaListCollectionQuery, variables, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fetch()
+	fetch()
+	if requests != 1 {
+		t.Errorf("expected the second request to be cached but got %d requests", requests)
+	}
+
+	// Adding a media that is not on the list yet changes the list as well.
+	invalidateListEntry(5114)
+	fetch()
+	if requests != 2 {
+		t.Errorf("expected the list to be fetched again but got %d requests", requests)
+	}
+}
+
+func TestDoRequestDoesNotCacheErrors(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"data": {"MediaList": null}, "errors": [{"message": "Not Found.", "status": 404}]}`))
+	}))
+	defer server.Close()
+
+	SetCache(NewMemoryCache(100), DefaultCacheTTL)
+	defer SetCache(nil, CacheTTL{})
+
+	variables := map[string]interface{}{"userName": "Ithilias", "mediaId": 21}
+	for i := 0; i < 2; i++ {
+		if _, _, err := doRequest(context.Background(), server.URL, ProgressQuery, variables, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("expected responses with errors not to be cached but got %d requests", requests)
+	}
+}
+
+func TestResponseMediaIDs(t *testing.T) {
+	data := map[string]json.RawMessage{
+		"b0": json.RawMessage(`{"id": 21}`),
+		"b1": json.RawMessage(`{"lists": [{"entries": [{"mediaId": 5114}, {"mediaId": 1535}]}]}`),
+	}
+	query := "query { b0: Media(id: 21) { id } b1: MediaListCollection(userName: \"a\") { lists { entries { mediaId } } } }"
+
+	ids := responseMediaIDs(query, map[string]interface{}{"b2_mediaId": 30}, data)
+	for _, id := range []int{21, 5114, 1535, 30} {
+		if !ids[id] {
+			t.Errorf("expected media %d in %v", id, ids)
+		}
+	}
+	if len(ids) != 4 {
+		t.Errorf("expected 4 media IDs but got %v", ids)
+	}
+}
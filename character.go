@@ -0,0 +1,227 @@
+package anilistgo
+
+import (
+	"fmt"
+)
+
+const (
+	CharacterQuery = `
+    query ($id: Int, $page: Int, $perPage: Int) {
+      Character (id: $id) {
+        id
+        name {
+          first
+          middle
+          last
+          full
+          native
+          alternative
+        }
+        image {
+          large
+          medium
+        }
+        description
+        gender
+        age
+        dateOfBirth {
+          year
+          month
+          day
+        }
+        favourites
+        siteUrl
+        media (page: $page, perPage: $perPage, sort: POPULARITY_DESC) {
+          pageInfo {
+            total
+            perPage
+            currentPage
+            lastPage
+            hasNextPage
+          }
+          edges {
+            characterRole
+            node {
+              id
+              type
+              format
+              title {
+                romaji
+                english
+                native
+              }
+              coverImage {
+                extraLarge
+              }
+            }
+            voiceActorRoles (sort: [LANGUAGE, RELEVANCE]) {
+              roleNotes
+              dubGroup
+              voiceActor {
+                id
+                name {
+                  full
+                  native
+                }
+                languageV2
+                image {
+                  large
+                  medium
+                }
+                siteUrl
+              }
+            }
+          }
+        }
+      }
+    }
+    `
+
+	CharacterSearchQuery = `
+    query ($search: String, $page: Int, $perPage: Int) {
+      Page (page: $page, perPage: $perPage) {
+        pageInfo {
+          total
+          perPage
+          currentPage
+          lastPage
+          hasNextPage
+        }
+        characters (search: $search, sort: [SEARCH_MATCH]) {
+          id
+          name {
+            first
+            middle
+            last
+            full
+            native
+            alternative
+          }
+          image {
+            large
+            medium
+          }
+          favourites
+          siteUrl
+        }
+      }
+    }
+    `
+)
+
+// PersonName is the name of a character or a staff member.
+type PersonName struct {
+	First       string   `json:"first"`
+	Middle      string   `json:"middle"`
+	Last        string   `json:"last"`
+	Full        string   `json:"full"`
+	Native      string   `json:"native"`
+	Alternative []string `json:"alternative"`
+}
+
+type Image struct {
+	Large  string `json:"large"`
+	Medium string `json:"medium"`
+}
+
+type Character struct {
+	ID          int                       `json:"id"`
+	Name        PersonName                `json:"name"`
+	Image       Image                     `json:"image"`
+	Description string                    `json:"description"`
+	Gender      string                    `json:"gender"`
+	Age         string                    `json:"age"`
+	DateOfBirth FuzzyDate                 `json:"dateOfBirth"`
+	Favourites  int                       `json:"favourites"`
+	SiteURL     string                    `json:"siteUrl"`
+	Media       *CharacterMediaConnection `json:"media,omitempty"`
+}
+
+// CharacterMediaConnection is one page of the media a character appears in.
+type CharacterMediaConnection struct {
+	PageInfo PageInfo             `json:"pageInfo"`
+	Edges    []CharacterMediaEdge `json:"edges"`
+}
+
+// CharacterMediaEdge is a media appearance of a character, with the role of
+// the character in it and the voice actors playing it in every language.
+type CharacterMediaEdge struct {
+	CharacterRole   string           `json:"characterRole"`
+	Node            Media            `json:"node"`
+	VoiceActorRoles []VoiceActorRole `json:"voiceActorRoles"`
+}
+
+type VoiceActorRole struct {
+	RoleNotes  string `json:"roleNotes"`
+	DubGroup   string `json:"dubGroup"`
+	VoiceActor Staff  `json:"voiceActor"`
+}
+
+// GetCharacterByID retrieves a character along with one page of the media it
+// appears in, sorted by popularity.
+//
+// Parameters:
+//   - id: The AniList ID of the character.
+//   - page: The page of media appearances to fetch, starting at 1.
+//   - perPage: The number of media appearances per page, at most 50.
+//
+// Returns:
+//   - The Character, where Media holds the requested page of appearances.
+//   - An error if there's any issue fetching the data or the character does
+//     not exist.
+//
+// Usage:
+//
+//	appearances, err := CollectPages(func(page int) ([]CharacterMediaEdge, PageInfo, error) {
+//	    character, err := GetCharacterByID(40, page, MaxPerPage)
+//	    if err != nil || character.Media == nil {
+//	        return nil, PageInfo{}, err
+//	    }
+//	    return character.Media.Edges, character.Media.PageInfo, nil
+//	})
+func GetCharacterByID(id int, page int, perPage int) (Character, error) {
+	variables := map[string]interface{}{
+		"id":      id,
+		"page":    page,
+		"perPage": perPage,
+	}
+
+	data, err := sendRequest(BaseAPIURL, CharacterQuery, variables, "")
+	if err != nil {
+		return Character{}, err
+	}
+	if data.Data.Character == nil {
+		return Character{}, fmt.Errorf("character %d not found", id)
+	}
+	return *data.Data.Character, nil
+}
+
+// SearchCharacters retrieves one page of characters matching the search
+// string, best match first.
+func SearchCharacters(search string, page int, perPage int) ([]Character, PageInfo, error) {
+	variables := map[string]interface{}{
+		"search":  search,
+		"page":    page,
+		"perPage": perPage,
+	}
+
+	data, err := sendRequest(BaseAPIURL, CharacterSearchQuery, variables, "")
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	if data.Data.Page == nil {
+		return nil, PageInfo{}, nil
+	}
+	return data.Data.Page.Characters, data.Data.Page.PageInfo, nil
+}
+
+// VoiceActorsByLanguage groups the voice actors of this appearance by their
+// language, such as "Japanese" or "English".
+func (e CharacterMediaEdge) VoiceActorsByLanguage() map[string][]Staff {
+	byLanguage := make(map[string][]Staff)
+	for _, role := range e.VoiceActorRoles {
+		language := role.VoiceActor.Language
+		byLanguage[language] = append(byLanguage[language], role.VoiceActor)
+	}
+	return byLanguage
+}
@@ -0,0 +1,20 @@
+package anilistgo
+
+import (
+	"testing"
+)
+
+func TestVoiceActorsByLanguage(t *testing.T) {
+	edge := CharacterMediaEdge{
+		VoiceActorRoles: []VoiceActorRole{
+			{VoiceActor: Staff{ID: 1, Language: "Japanese"}},
+			{VoiceActor: Staff{ID: 2, Language: "English"}},
+			{VoiceActor: Staff{ID: 3, Language: "English"}},
+		},
+	}
+
+	byLanguage := edge.VoiceActorsByLanguage()
+	if len(byLanguage["Japanese"]) != 1 || len(byLanguage["English"]) != 2 || byLanguage["English"][1].ID != 3 {
+		t.Errorf("expected voice actors grouped by language but got %v", byLanguage)
+	}
+}
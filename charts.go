@@ -0,0 +1,179 @@
+package anilistgo
+
+import (
+	"time"
+)
+
+const (
+	// MediaChartQuery is kept for compatibility and selects a fixed set of
+	// fields.
+	//
+	// Deprecated: the chart functions select every field of Media, see
+	// SelectionSet.
+	MediaChartQuery = `
+    query ($page: Int, $perPage: Int, $sort: [MediaSort], $type: MediaType, $season: MediaSeason, $seasonYear: Int, $formatIn: [MediaFormat], $genreIn: [String]) {
+      Page (page: $page, perPage: $perPage) {
+        pageInfo {
+          total
+          perPage
+          currentPage
+          lastPage
+          hasNextPage
+        }
+        media (sort: $sort, type: $type, season: $season, seasonYear: $seasonYear, format_in: $formatIn, genre_in: $genreIn, isAdult: false) {
+          id
+          idMal
+          type
+          format
+          status
+          title {
+            romaji
+            english
+            native
+          }
+          coverImage {
+            extraLarge
+          }
+          episodes
+          chapters
+          volumes
+          duration
+          season
+          seasonYear
+          startDate {
+            year
+            month
+            day
+          }
+          genres
+          averageScore
+          meanScore
+          popularity
+          trending
+          favourites
+          siteUrl
+          nextAiringEpisode {
+            id
+            airingAt
+            timeUntilAiring
+            episode
+            mediaId
+          }
+        }
+      }
+    }
+    `
+)
+
+var mediaChartQuery = `query ($page: Int, $perPage: Int, $sort: [MediaSort], $type: MediaType, $season: MediaSeason, $seasonYear: Int, $formatIn: [MediaFormat], $genreIn: [String]) { Page (page: $page, perPage: $perPage) { pageInfo { total perPage currentPage lastPage hasNextPage } media (sort: $sort, type: $type, season: $season, seasonYear: $seasonYear, format_in: $formatIn, genre_in: $genreIn, isAdult: false) ` + SelectionSet(Media{}) + ` } }`
+
+// ChartFilter narrows down the media of the chart queries. Type defaults to
+// MediaTypeAnime. Formats and Genres match media with any of the given
+// values, such as "TV" or "Action".
+type ChartFilter struct {
+	Type    string
+	Formats []string
+	Genres  []string
+}
+
+// CurrentSeason returns the anime season and year that the given time falls
+// in, such as "SPRING" and 2024.
+func CurrentSeason(now time.Time) (string, int) {
+	return computeSeason(now, 0)
+}
+
+// NextSeason returns the anime season and year following the one that the
+// given time falls in.
+func NextSeason(now time.Time) (string, int) {
+	return computeSeason(now, 1)
+}
+
+// GetSeasonalMedia retrieves one page of the media of a season, most popular
+// first.
+//
+// Parameters:
+//   - season: One of the AnimeSeasons, such as "SPRING".
+//   - year: The year of the season.
+//   - filter: The ChartFilter to apply.
+//   - page: The page to fetch, starting at 1.
+//   - perPage: The number of media per page, at most 50.
+//
+// Returns:
+//   - A slice of Media structs.
+//   - The PageInfo of the fetched page, to know whether there is a next page.
+//   - An error if there's any issue fetching the data.
+//
+// Usage:
+//
+//	season, year := CurrentSeason(time.Now())
+//	media, _, err := GetSeasonalMedia(season, year, ChartFilter{Formats: []string{"TV"}}, 1, 10)
+func GetSeasonalMedia(season string, year int, filter ChartFilter, page int, perPage int) ([]Media, PageInfo, error) {
+	variables := filter.variables()
+	variables["season"] = season
+	variables["seasonYear"] = year
+	return fetchMediaChart([]string{"POPULARITY_DESC"}, variables, page, perPage)
+}
+
+// GetCurrentSeasonMedia retrieves one page of this season's media, most
+// popular first.
+func GetCurrentSeasonMedia(filter ChartFilter, page int, perPage int) ([]Media, PageInfo, error) {
+	season, year := CurrentSeason(time.Now())
+	return GetSeasonalMedia(season, year, filter, page, perPage)
+}
+
+// GetNextSeasonMedia retrieves one page of next season's media, most popular
+// first.
+func GetNextSeasonMedia(filter ChartFilter, page int, perPage int) ([]Media, PageInfo, error) {
+	season, year := NextSeason(time.Now())
+	return GetSeasonalMedia(season, year, filter, page, perPage)
+}
+
+// GetTrendingMedia retrieves one page of the media that are trending right
+// now.
+func GetTrendingMedia(filter ChartFilter, page int, perPage int) ([]Media, PageInfo, error) {
+	return fetchMediaChart([]string{"TRENDING_DESC", "POPULARITY_DESC"}, filter.variables(), page, perPage)
+}
+
+// GetPopularMedia retrieves one page of the most popular media of all time.
+func GetPopularMedia(filter ChartFilter, page int, perPage int) ([]Media, PageInfo, error) {
+	return fetchMediaChart([]string{"POPULARITY_DESC"}, filter.variables(), page, perPage)
+}
+
+// GetTopScoredMedia retrieves one page of the highest scored media of all
+// time.
+func GetTopScoredMedia(filter ChartFilter, page int, perPage int) ([]Media, PageInfo, error) {
+	return fetchMediaChart([]string{"SCORE_DESC"}, filter.variables(), page, perPage)
+}
+
+func (f ChartFilter) variables() map[string]interface{} {
+	mediaType := f.Type
+	if mediaType == "" {
+		mediaType = MediaTypeAnime
+	}
+
+	variables := map[string]interface{}{
+		"type": mediaType,
+	}
+	if len(f.Formats) > 0 {
+		variables["formatIn"] = f.Formats
+	}
+	if len(f.Genres) > 0 {
+		variables["genreIn"] = f.Genres
+	}
+	return variables
+}
+
+func fetchMediaChart(sort []string, variables map[string]interface{}, page int, perPage int) ([]Media, PageInfo, error) {
+	variables["sort"] = sort
+	variables["page"] = page
+	variables["perPage"] = perPage
+
+	data, err := sendRequest(BaseAPIURL, mediaChartQuery, variables, "")
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	if data.Data.Page == nil {
+		return nil, PageInfo{}, nil
+	}
+	return data.Data.Page.Media, data.Data.Page.PageInfo, nil
+}
@@ -0,0 +1,41 @@
+package anilistgo
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCurrentAndNextSeason(t *testing.T) {
+	tests := []struct {
+		date          string
+		currentSeason string
+		currentYear   int
+		nextSeason    string
+		nextYear      int
+	}{
+		{"2024-02-10", "WINTER", 2024, "SPRING", 2024},
+		{"2024-07-01", "SUMMER", 2024, "FALL", 2024},
+		{"2024-11-30", "FALL", 2024, "WINTER", 2025},
+	}
+
+	for _, tt := range tests {
+		now, _ := time.Parse("2006-01-02", tt.date)
+
+		season, year := CurrentSeason(now)
+		if season != tt.currentSeason || year != tt.currentYear {
+			t.Errorf("expected current season %s %d for %s but got %s %d", tt.currentSeason, tt.currentYear, tt.date, season, year)
+		}
+
+		season, year = NextSeason(now)
+		if season != tt.nextSeason || year != tt.nextYear {
+			t.Errorf("expected next season %s %d for %s but got %s %d", tt.nextSeason, tt.nextYear, tt.date, season, year)
+		}
+	}
+}
+
+func TestMediaChartQuerySelectsMedia(t *testing.T) {
+	if !strings.Contains(mediaChartQuery, "isAdult: false) "+SelectionSet(Media{})+" }") {
+		t.Errorf("expected the chart query to select every field of Media but got %q", mediaChartQuery)
+	}
+}
@@ -0,0 +1,515 @@
+// Command anilistgen generates Go types from an AniList GraphQL schema
+// introspection file, along with typed wrappers for the operations of
+// .graphql files. It is run by go generate in the anilistgo package:
+//
+//	anilistgen -schema schema/anilist.json -types MediaListStatus,MediaListCollection -map MediaList=MediaListEntry -operations graphql -o generated.go
+//
+// Enums become string types with one constant per value, and objects and
+// input objects become structs. Every object or input object referenced by a
+// generated type must either be generated as well or mapped to an existing
+// Go type with -map.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const header = "// Code generated by anilistgen. DO NOT EDIT.\n\n"
+
+type introspection struct {
+	Data struct {
+		Schema schema `json:"__schema"`
+	} `json:"data"`
+}
+
+type schema struct {
+	QueryType    *typeName  `json:"queryType"`
+	MutationType *typeName  `json:"mutationType"`
+	Types        []fullType `json:"types"`
+}
+
+type typeName struct {
+	Name string `json:"name"`
+}
+
+type fullType struct {
+	Kind        string       `json:"kind"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Fields      []field      `json:"fields"`
+	InputFields []inputValue `json:"inputFields"`
+	EnumValues  []enumValue  `json:"enumValues"`
+}
+
+type field struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Type        typeRef `json:"type"`
+}
+
+type inputValue struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Type        typeRef `json:"type"`
+}
+
+type enumValue struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type typeRef struct {
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	OfType *typeRef `json:"ofType"`
+}
+
+type generator struct {
+	schema   schema
+	types    map[string]fullType
+	generate []string
+	mapped   map[string]string
+	pkg      string
+}
+
+var scalars = map[string]string{
+	"Int":          "int",
+	"Float":        "float64",
+	"String":       "string",
+	"Boolean":      "bool",
+	"ID":           "int",
+	"FuzzyDateInt": "int",
+	"CountryCode":  "string",
+	"Json":         "json.RawMessage",
+}
+
+var initialisms = map[string]string{
+	"api":  "API",
+	"html": "HTML",
+	"id":   "ID",
+	"json": "JSON",
+	"mal":  "MAL",
+	"ona":  "ONA",
+	"ova":  "OVA",
+	"tv":   "TV",
+	"url":  "URL",
+}
+
+func main() {
+	schemaPath := flag.String("schema", "", "path of the schema introspection JSON file")
+	typeList := flag.String("types", "", "comma separated schema types to generate")
+	mapList := flag.String("map", "", "comma separated GraphQLType=GoType pairs of existing types")
+	operations := flag.String("operations", "", "directory of .graphql files to generate wrappers for")
+	pkg := flag.String("package", "anilistgo", "package name of the generated file")
+	output := flag.String("o", "generated.go", "path of the generated file")
+	flag.Parse()
+
+	if err := run(*schemaPath, *typeList, *mapList, *operations, *pkg, *output); err != nil {
+		fmt.Fprintln(os.Stderr, "anilistgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaPath string, typeList string, mapList string, operationsDir string, pkg string, output string) error {
+	data, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return err
+	}
+	g, err := newGenerator(data, splitList(typeList), splitList(mapList), pkg)
+	if err != nil {
+		return err
+	}
+
+	var operations []operation
+	if operationsDir != "" {
+		paths, err := filepath.Glob(filepath.Join(operationsDir, "*.graphql"))
+		if err != nil {
+			return err
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			document, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			op, err := parseOperation(string(document))
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			op.file = filepath.Base(path)
+			operations = append(operations, op)
+		}
+	}
+
+	source, err := g.generateFile(operations)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(output, source, 0o644)
+}
+
+func newGenerator(data []byte, generate []string, mappings []string, pkg string) (*generator, error) {
+	var result introspection
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("parsing schema: %w", err)
+	}
+
+	g := &generator{
+		schema:   result.Data.Schema,
+		types:    make(map[string]fullType),
+		generate: generate,
+		mapped:   make(map[string]string),
+		pkg:      pkg,
+	}
+	for _, t := range g.schema.Types {
+		g.types[t.Name] = t
+	}
+	for _, name := range generate {
+		if _, ok := g.types[name]; !ok {
+			return nil, fmt.Errorf("type %s is not in the schema", name)
+		}
+	}
+	for _, mapping := range mappings {
+		graphQLType, goType, ok := strings.Cut(mapping, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid mapping %q, expected GraphQLType=GoType", mapping)
+		}
+		g.mapped[graphQLType] = goType
+	}
+	return g, nil
+}
+
+func (g *generator) generateFile(operations []operation) ([]byte, error) {
+	var body bytes.Buffer
+	for _, name := range g.generate {
+		t := g.types[name]
+		var err error
+		switch t.Kind {
+		case "ENUM":
+			g.writeEnum(&body, t)
+		case "OBJECT":
+			err = g.writeStruct(&body, t, t.Fields, false)
+		case "INPUT_OBJECT":
+			fields := make([]field, len(t.InputFields))
+			for i, input := range t.InputFields {
+				fields[i] = field(input)
+			}
+			err = g.writeStruct(&body, t, fields, true)
+		default:
+			err = fmt.Errorf("cannot generate %s type %s", strings.ToLower(t.Kind), t.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, op := range operations {
+		if err := g.writeOperation(&body, op); err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	out.WriteString(header)
+	fmt.Fprintf(&out, "package %s\n\n", g.pkg)
+	var imports []string
+	if bytes.Contains(body.Bytes(), []byte("context.Context")) {
+		imports = append(imports, `"context"`)
+	}
+	if bytes.Contains(body.Bytes(), []byte("json.RawMessage")) {
+		imports = append(imports, `"encoding/json"`)
+	}
+	if len(imports) > 0 {
+		fmt.Fprintf(&out, "import (\n%s\n)\n\n", strings.Join(imports, "\n"))
+	}
+	out.Write(body.Bytes())
+
+	source, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w\n%s", err, out.String())
+	}
+	return source, nil
+}
+
+func (g *generator) writeEnum(w *bytes.Buffer, t fullType) {
+	writeComment(w, t.Name, t.Description)
+	fmt.Fprintf(w, "type %s string\n\nconst (\n", t.Name)
+	for _, value := range t.EnumValues {
+		if value.Description != "" {
+			fmt.Fprintf(w, "// %s\n", value.Description)
+		}
+		fmt.Fprintf(w, "%s%s %s = %q\n", t.Name, goName(value.Name), t.Name, value.Name)
+	}
+	w.WriteString(")\n\n")
+}
+
+// writeStruct writes an object or input object. Nullable fields of input
+// objects are pointers, so that unset fields can be left out.
+func (g *generator) writeStruct(w *bytes.Buffer, t fullType, fields []field, input bool) error {
+	writeComment(w, t.Name, t.Description)
+	fmt.Fprintf(w, "type %s struct {\n", t.Name)
+	for _, f := range fields {
+		goType, err := g.goType(f.Type, input)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t.Name, f.Name, err)
+		}
+		tag := f.Name
+		if strings.HasPrefix(goType, "*") || (input && f.Type.Kind != "NON_NULL") {
+			tag += ",omitempty"
+		}
+		if f.Description != "" {
+			fmt.Fprintf(w, "// %s\n", f.Description)
+		}
+		fmt.Fprintf(w, "%s %s `json:\"%s\"`\n", goName(f.Name), goType, tag)
+	}
+	w.WriteString("}\n\n")
+	return nil
+}
+
+func (g *generator) writeOperation(w *bytes.Buffer, op operation) error {
+	rootTypeName := g.rootType(op.kind)
+	root, ok := g.types[rootTypeName]
+	if !ok {
+		return fmt.Errorf("%s: the schema has no %s type", op.file, op.kind)
+	}
+
+	fmt.Fprintf(w, "// %sDocument is the %s of %s.\n", op.name, op.kind, op.file)
+	document := strings.TrimSpace(op.document)
+	if strings.Contains(document, "`") {
+		fmt.Fprintf(w, "const %sDocument = %q\n\n", op.name, document)
+	} else {
+		fmt.Fprintf(w, "const %sDocument = `%s`\n\n", op.name, document)
+	}
+
+	fmt.Fprintf(w, "// %sVariables are the variables of %sDocument. Zero values are not sent.\n", op.name, op.name)
+	fmt.Fprintf(w, "type %sVariables struct {\n", op.name)
+	for _, variable := range op.variables {
+		ref, err := g.parseTypeRef(variable.typeName)
+		if err != nil {
+			return fmt.Errorf("%s: variable $%s: %w", op.file, variable.name, err)
+		}
+		goType, err := g.goType(ref, false)
+		if err != nil {
+			return fmt.Errorf("%s: variable $%s: %w", op.file, variable.name, err)
+		}
+		tag := variable.name
+		if ref.Kind != "NON_NULL" {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(w, "%s %s `json:\"%s\"`\n", goName(variable.name), goType, tag)
+	}
+	w.WriteString("}\n\n")
+
+	fmt.Fprintf(w, "// %sResult is the data returned by %sDocument.\n", op.name, op.name)
+	fmt.Fprintf(w, "type %sResult struct {\n", op.name)
+	for _, selection := range op.fields {
+		rootField, ok := findField(root.Fields, selection.name)
+		if !ok {
+			return fmt.Errorf("%s: the %s type has no field %s", op.file, rootTypeName, selection.name)
+		}
+		goType, err := g.goType(rootField.Type, false)
+		if err != nil {
+			return fmt.Errorf("%s: field %s: %w", op.file, selection.name, err)
+		}
+		fmt.Fprintf(w, "%s %s `json:\"%s\"`\n", goName(selection.key), goType, selection.key)
+	}
+	w.WriteString("}\n\n")
+
+	receiver, query := "", "Query"
+	if op.kind == "mutation" {
+		receiver, query = "(api *AuthenticatedAPI) ", "api.Query"
+		fmt.Fprintf(w, "// %s sends the %s mutation to AniList as the authenticated user.\n", op.name, op.name)
+	} else {
+		fmt.Fprintf(w, "// %s sends the %s query to AniList.\n", op.name, op.name)
+	}
+	fmt.Fprintf(w, "func %s%s(ctx context.Context, vars %sVariables) (%sResult, error) {\n", receiver, op.name, op.name, op.name)
+	fmt.Fprintf(w, "var result %sResult\n", op.name)
+	fmt.Fprintf(w, "err := %s(ctx, %sDocument, vars, &result)\n", query, op.name)
+	w.WriteString("return result, err\n}\n\n")
+	return nil
+}
+
+// goType returns the Go type of a schema type. Nullable objects are
+// pointers, and so are nullable scalars and enums if pointerScalars is set.
+func (g *generator) goType(ref typeRef, pointerScalars bool) (string, error) {
+	nullable := true
+	if ref.Kind == "NON_NULL" {
+		if ref.OfType == nil {
+			return "", fmt.Errorf("non-null type without an inner type")
+		}
+		nullable = false
+		ref = *ref.OfType
+	}
+
+	if ref.Kind == "LIST" {
+		if ref.OfType == nil {
+			return "", fmt.Errorf("list type without an element type")
+		}
+		element := *ref.OfType
+		if element.Kind == "NON_NULL" && element.OfType != nil {
+			element = *element.OfType
+		}
+		elementType, err := g.goType(typeRef{Kind: "NON_NULL", OfType: &element}, false)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elementType, nil
+	}
+
+	var goType string
+	pointer := nullable
+	switch ref.Kind {
+	case "SCALAR":
+		goType = scalars[ref.Name]
+		if goType == "" {
+			goType = "json.RawMessage"
+		}
+		pointer = nullable && pointerScalars && goType != "json.RawMessage"
+	case "ENUM":
+		goType = "string"
+		if mapped, ok := g.mapped[ref.Name]; ok {
+			goType = mapped
+		} else if g.isGenerated(ref.Name) {
+			goType = ref.Name
+		}
+		pointer = nullable && pointerScalars
+	case "OBJECT", "INPUT_OBJECT":
+		if mapped, ok := g.mapped[ref.Name]; ok {
+			goType = mapped
+		} else if g.isGenerated(ref.Name) {
+			goType = ref.Name
+		} else {
+			return "", fmt.Errorf("type %s is neither generated nor mapped", ref.Name)
+		}
+	default:
+		return "", fmt.Errorf("unsupported %s type %s", strings.ToLower(ref.Kind), ref.Name)
+	}
+
+	if pointer {
+		return "*" + goType, nil
+	}
+	return goType, nil
+}
+
+// parseTypeRef parses the type of a variable, such as "[Int!]!".
+func (g *generator) parseTypeRef(typeName string) (typeRef, error) {
+	typeName = strings.TrimSpace(typeName)
+	if strings.HasSuffix(typeName, "!") {
+		inner, err := g.parseTypeRef(strings.TrimSuffix(typeName, "!"))
+		if err != nil {
+			return typeRef{}, err
+		}
+		return typeRef{Kind: "NON_NULL", OfType: &inner}, nil
+	}
+	if strings.HasPrefix(typeName, "[") && strings.HasSuffix(typeName, "]") {
+		inner, err := g.parseTypeRef(typeName[1 : len(typeName)-1])
+		if err != nil {
+			return typeRef{}, err
+		}
+		return typeRef{Kind: "LIST", OfType: &inner}, nil
+	}
+
+	t, ok := g.types[typeName]
+	if !ok {
+		if _, ok := scalars[typeName]; ok {
+			return typeRef{Kind: "SCALAR", Name: typeName}, nil
+		}
+		return typeRef{}, fmt.Errorf("unknown type %s", typeName)
+	}
+	return typeRef{Kind: t.Kind, Name: t.Name}, nil
+}
+
+func (g *generator) rootType(kind string) string {
+	if kind == "mutation" && g.schema.MutationType != nil {
+		return g.schema.MutationType.Name
+	}
+	if kind == "query" && g.schema.QueryType != nil {
+		return g.schema.QueryType.Name
+	}
+	return ""
+}
+
+func (g *generator) isGenerated(name string) bool {
+	for _, generated := range g.generate {
+		if generated == name {
+			return true
+		}
+	}
+	return false
+}
+
+func findField(fields []field, name string) (field, bool) {
+	for _, f := range fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return field{}, false
+}
+
+func writeComment(w *bytes.Buffer, name string, description string) {
+	if description == "" {
+		return
+	}
+	fmt.Fprintf(w, "// %s: %s\n", name, strings.ReplaceAll(description, "\n", "\n// "))
+}
+
+// goName converts a GraphQL name, such as "siteUrl" or "POINT_10_DECIMAL",
+// to an exported Go name, such as "SiteURL" or "Point10Decimal".
+func goName(name string) string {
+	var words []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, strings.ToLower(string(current)))
+			current = nil
+		}
+	}
+
+	runes := []rune(name)
+	allUpper := strings.ToUpper(name) == name
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-':
+			flush()
+			continue
+		case !allUpper && unicode.IsUpper(r) && i > 0 && !unicode.IsUpper(runes[i-1]):
+			flush()
+		case unicode.IsDigit(r) && i > 0 && !unicode.IsDigit(runes[i-1]):
+			flush()
+		case !unicode.IsDigit(r) && i > 0 && unicode.IsDigit(runes[i-1]):
+			flush()
+		}
+		current = append(current, r)
+	}
+	flush()
+
+	var b strings.Builder
+	for _, word := range words {
+		if initialism, ok := initialisms[word]; ok {
+			b.WriteString(initialism)
+			continue
+		}
+		r := []rune(word)
+		b.WriteString(string(unicode.ToUpper(r[0])) + string(r[1:]))
+	}
+	return b.String()
+}
+
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
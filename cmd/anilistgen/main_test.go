@@ -0,0 +1,159 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const testSchema = `{"data": {"__schema": {
+  "queryType": {"name": "Query"},
+  "mutationType": {"name": "Mutation"},
+  "types": [
+    {"kind": "OBJECT", "name": "Query", "fields": [
+      {"name": "Media", "type": {"kind": "OBJECT", "name": "Media"}}
+    ]},
+    {"kind": "OBJECT", "name": "Mutation", "fields": [
+      {"name": "DeleteMedia", "type": {"kind": "SCALAR", "name": "Boolean"}}
+    ]},
+    {"kind": "OBJECT", "name": "Media", "description": "Anime or manga", "fields": [
+      {"name": "id", "type": {"kind": "NON_NULL", "ofType": {"kind": "SCALAR", "name": "Int"}}},
+      {"name": "siteUrl", "type": {"kind": "SCALAR", "name": "String"}},
+      {"name": "format", "type": {"kind": "ENUM", "name": "MediaFormat"}},
+      {"name": "synonyms", "type": {"kind": "LIST", "ofType": {"kind": "SCALAR", "name": "String"}}}
+    ]},
+    {"kind": "ENUM", "name": "MediaFormat", "enumValues": [
+      {"name": "TV", "description": "Anime broadcast on television"},
+      {"name": "TV_SHORT"}
+    ]},
+    {"kind": "INPUT_OBJECT", "name": "FuzzyDateInput", "inputFields": [
+      {"name": "year", "type": {"kind": "SCALAR", "name": "Int"}}
+    ]},
+    {"kind": "SCALAR", "name": "Int"},
+    {"kind": "SCALAR", "name": "String"},
+    {"kind": "SCALAR", "name": "Boolean"}
+  ]
+}}}`
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{
+		"siteUrl":          "SiteURL",
+		"idMal":            "IDMAL",
+		"POINT_10_DECIMAL": "Point10Decimal",
+		"TV_SHORT":         "TVShort",
+		"isCustomList":     "IsCustomList",
+		"perChunk":         "PerChunk",
+	}
+	for name, expected := range tests {
+		if result := goName(name); result != expected {
+			t.Errorf("goName(%q): expected %q but got %q", name, expected, result)
+		}
+	}
+}
+
+func TestParseOperation(t *testing.T) {
+	document := `
+# A comment with a "quote" in it
+query Find($id: Int!, $search: String = "a, b", $formats: [MediaFormat!]) {
+  media: Media(id: $id, search: $search, format_in: $formats) @include(if: true) {
+    id
+  }
+}
+
+fragment Unused on Media {
+  siteUrl
+}
+`
+	op, err := parseOperation(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if op.kind != "query" || op.name != "Find" {
+		t.Errorf("expected query Find but got %s %s", op.kind, op.name)
+	}
+	expectedVariables := []variable{{"id", "Int!"}, {"search", "String"}, {"formats", "[MediaFormat!]"}}
+	if len(op.variables) != len(expectedVariables) {
+		t.Fatalf("expected variables %v but got %v", expectedVariables, op.variables)
+	}
+	for i, v := range expectedVariables {
+		if op.variables[i] != v {
+			t.Errorf("expected variable %v but got %v", v, op.variables[i])
+		}
+	}
+	if len(op.fields) != 1 || op.fields[0] != (rootField{key: "media", name: "Media"}) {
+		t.Errorf("expected the aliased Media field but got %v", op.fields)
+	}
+
+	for _, invalid := range []string{"{ Media { id } }", "query { Media { id } }", "query A { Media } query B { Media }", `query A { Media(search: "x) }`} {
+		if _, err := parseOperation(invalid); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestGenerateFile(t *testing.T) {
+	g, err := newGenerator([]byte(testSchema), []string{"MediaFormat", "Media", "FuzzyDateInput"}, nil, "anilist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, err := parseOperation(`query GetMedia($id: Int) { Media(id: $id) { id } }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query.file = "get_media.graphql"
+	mutation, err := parseOperation(`mutation Delete { DeleteMedia }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mutation.file = "delete.graphql"
+
+	source, err := g.generateFile([]operation{query, mutation})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		header + "package anilist\n",
+		"MediaFormatTVShort MediaFormat = \"TV_SHORT\"",
+		"ID       int         `json:\"id\"`",
+		"SiteURL  string      `json:\"siteUrl\"`",
+		"Format   MediaFormat `json:\"format\"`",
+		"Synonyms []string    `json:\"synonyms\"`",
+		"Year *int `json:\"year,omitempty\"`",
+		"ID int `json:\"id,omitempty\"`",
+		"Media *Media `json:\"Media\"`",
+		"func GetMedia(ctx context.Context, vars GetMediaVariables) (GetMediaResult, error)",
+		"func (api *AuthenticatedAPI) Delete(ctx context.Context, vars DeleteVariables) (DeleteResult, error)",
+		"err := api.Query(ctx, DeleteDocument, vars, &result)",
+	} {
+		if !strings.Contains(string(source), expected) {
+			t.Errorf("expected %q in the generated code:\n%s", expected, source)
+		}
+	}
+}
+
+func TestGenerateFileUnmappedType(t *testing.T) {
+	g, err := newGenerator([]byte(testSchema), []string{"Query"}, nil, "anilist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.generateFile(nil); err == nil || !strings.Contains(err.Error(), "Media is neither generated nor mapped") {
+		t.Errorf("expected an error about the unmapped Media type but got %v", err)
+	}
+
+	g, err = newGenerator([]byte(testSchema), []string{"Query"}, []string{"Media=MediaItem"}, "anilist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := g.generateFile(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(source), "Media *MediaItem `json:\"Media,omitempty\"`") {
+		t.Errorf("expected the mapped Media type in the generated code:\n%s", source)
+	}
+
+	if _, err := newGenerator([]byte(testSchema), []string{"Missing"}, nil, "anilist"); err == nil {
+		t.Error("expected an error for a type missing from the schema")
+	}
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// operation is the single query or mutation of a .graphql file. Only what
+// the wrappers need is parsed: the variables and the root fields.
+type operation struct {
+	file      string
+	document  string
+	kind      string
+	name      string
+	variables []variable
+	fields    []rootField
+}
+
+type variable struct {
+	name     string
+	typeName string
+}
+
+// rootField is a field selected on the root type, where key is the alias if
+// there is one and the field name otherwise.
+type rootField struct {
+	key  string
+	name string
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func parseOperation(document string) (operation, error) {
+	tokens, err := tokenize(document)
+	if err != nil {
+		return operation{}, err
+	}
+	p := &parser{tokens: tokens}
+	op := operation{document: document}
+
+	for !p.done() {
+		switch keyword := p.next(); keyword {
+		case "fragment":
+			if err := p.skipUntil("{"); err != nil {
+				return operation{}, err
+			}
+			if err := p.skipBlock("{", "}"); err != nil {
+				return operation{}, err
+			}
+		case "query", "mutation":
+			if op.kind != "" {
+				return operation{}, fmt.Errorf("only one operation per file is supported")
+			}
+			op.kind = keyword
+			if err := p.parseOperation(&op); err != nil {
+				return operation{}, err
+			}
+		default:
+			return operation{}, fmt.Errorf("unexpected %q, expected an operation or a fragment", keyword)
+		}
+	}
+
+	if op.kind == "" {
+		return operation{}, fmt.Errorf("no operation found")
+	}
+	return op, nil
+}
+
+func (p *parser) parseOperation(op *operation) error {
+	if p.done() || !isName(p.peek()) {
+		return fmt.Errorf("the %s must be named", op.kind)
+	}
+	op.name = p.next()
+
+	if p.peek() == "(" {
+		p.next()
+		for p.peek() != ")" {
+			if p.done() {
+				return fmt.Errorf("unterminated variable definitions")
+			}
+			if p.next() != "$" || !isName(p.peek()) {
+				return fmt.Errorf("invalid variable definition in %s", op.name)
+			}
+			name := p.next()
+			if p.next() != ":" {
+				return fmt.Errorf("missing type of variable $%s", name)
+			}
+
+			var typeName strings.Builder
+			for !p.done() && p.peek() != "$" && p.peek() != ")" && p.peek() != "=" && p.peek() != "@" {
+				typeName.WriteString(p.next())
+			}
+			if p.peek() == "=" || p.peek() == "@" {
+				for !p.done() && p.peek() != "$" && p.peek() != ")" {
+					if err := p.skipValue(); err != nil {
+						return err
+					}
+				}
+			}
+			op.variables = append(op.variables, variable{name: name, typeName: typeName.String()})
+		}
+		p.next()
+	}
+
+	if err := p.skipUntil("{"); err != nil {
+		return err
+	}
+	p.next()
+	for p.peek() != "}" {
+		if p.done() {
+			return fmt.Errorf("unterminated selection set in %s", op.name)
+		}
+		token := p.next()
+		if token == "..." {
+			return fmt.Errorf("fragment spreads on the root type of %s are not supported", op.name)
+		}
+		if !isName(token) {
+			return fmt.Errorf("unexpected %q in the selection set of %s", token, op.name)
+		}
+
+		selected := rootField{key: token, name: token}
+		if p.peek() == ":" {
+			p.next()
+			selected.name = p.next()
+		}
+		for p.peek() == "(" || p.peek() == "@" || p.peek() == "{" {
+			if err := p.skipValue(); err != nil {
+				return err
+			}
+		}
+		op.fields = append(op.fields, selected)
+	}
+	p.next()
+	return nil
+}
+
+// skipValue skips a token, or a whole block if the token opens one. A
+// directive is skipped along with its arguments.
+func (p *parser) skipValue() error {
+	switch p.peek() {
+	case "(":
+		return p.skipBlock("(", ")")
+	case "{":
+		return p.skipBlock("{", "}")
+	case "[":
+		return p.skipBlock("[", "]")
+	case "@":
+		p.next()
+		p.next()
+		if p.peek() == "(" {
+			return p.skipBlock("(", ")")
+		}
+		return nil
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) skipBlock(open string, close string) error {
+	depth := 0
+	for !p.done() {
+		switch p.next() {
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("unterminated %s", open)
+}
+
+func (p *parser) skipUntil(token string) error {
+	for !p.done() && p.peek() != token {
+		if err := p.skipValue(); err != nil {
+			return err
+		}
+	}
+	if p.done() {
+		return fmt.Errorf("expected %q", token)
+	}
+	return nil
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+// tokenize splits a GraphQL document into names, numbers, strings and
+// punctuators, dropping whitespace, commas and comments.
+func tokenize(document string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(document); {
+		c := document[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			i++
+		case strings.HasPrefix(document[i:], "\ufeff"):
+			i += len("\ufeff")
+		case c == '#':
+			for i < len(document) && document[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(document[i:], `"""`):
+			end := strings.Index(document[i+3:], `"""`)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated block string")
+			}
+			tokens = append(tokens, document[i:i+3+end+3])
+			i += 3 + end + 3
+		case c == '"':
+			start := i
+			for i++; i < len(document) && document[i] != '"'; i++ {
+				if document[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(document) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			i++
+			tokens = append(tokens, document[start:i])
+		case strings.HasPrefix(document[i:], "..."):
+			tokens = append(tokens, "...")
+			i += 3
+		case strings.IndexByte("!$&()[]{}:=@|", c) >= 0:
+			tokens = append(tokens, string(c))
+			i++
+		case c == '_' || c == '-' || isLetter(c) || isDigit(c):
+			start := i
+			for i++; i < len(document) && (document[i] == '_' || document[i] == '.' || isLetter(document[i]) || isDigit(document[i])); i++ {
+			}
+			tokens = append(tokens, document[start:i])
+		default:
+			return nil, fmt.Errorf("unexpected character %q", c)
+		}
+	}
+	return tokens, nil
+}
+
+func isName(token string) bool {
+	if token == "" {
+		return false
+	}
+	for i := 0; i < len(token); i++ {
+		c := token[i]
+		if c != '_' && !isLetter(c) && (i == 0 || !isDigit(c)) {
+			return false
+		}
+	}
+	return true
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,243 @@
+package anilistgo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+const (
+	FieldStatus          = "status"
+	FieldScore           = "score"
+	FieldProgress        = "progress"
+	FieldProgressVolumes = "progressVolumes"
+	FieldRepeat          = "repeat"
+	FieldNotes           = "notes"
+	FieldStartedAt       = "startedAt"
+	FieldCompletedAt     = "completedAt"
+
+	DeleteMediaListEntryQuery = `
+    mutation ($id: Int) {
+      DeleteMediaListEntry (id: $id) {
+        deleted
+      }
+    }
+    `
+)
+
+// FieldChange is the change of a single field of a list entry, with both
+// values formatted as strings. Unset values are empty strings.
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// EntryChange describes a list entry that exists in both lists but differs.
+type EntryChange struct {
+	MediaID int
+	Before  MediaListEntry
+	After   MediaListEntry
+	Fields  []FieldChange
+}
+
+// ListDiff is the difference between two lists, as computed by DiffLists.
+// Added holds the entries only in the after list, Removed the entries only in
+// the before list, and Changed the entries in both with differing fields.
+type ListDiff struct {
+	Added   []MediaListEntry
+	Removed []MediaListEntry
+	Changed []EntryChange
+}
+
+// SyncPlan holds the operations that turn the before list of a ListDiff into
+// the after list. Delete holds the entries of the before list to delete.
+type SyncPlan struct {
+	Save   []SaveMediaListEntryOp
+	Delete []MediaListEntry
+}
+
+// DiffLists compares two lists of entries of the same media type, matched by
+// media ID. The lists can be the lists of two users, or two snapshots of the
+// same user, for example saved with WriteJSONLines and loaded with
+// ReadJSONLines[MediaListEntry].
+//
+// Usage:
+//
+//	before, err := ReadJSONLines[MediaListEntry](snapshot)
+//	after, err := GetMediaListCollection("Ithilias", MediaTypeAnime)
+//	diff := DiffLists(before, after)
+//	for _, change := range diff.Changed {
+//	    for _, field := range change.Fields {
+//	        fmt.Printf("%d: %s %s -> %s\n", change.MediaID, field.Field, field.Old, field.New)
+//	    }
+//	}
+func DiffLists(before []MediaListEntry, after []MediaListEntry) ListDiff {
+	beforeByID := make(map[int]MediaListEntry, len(before))
+	for _, entry := range before {
+		beforeByID[entry.MediaID] = entry
+	}
+	afterByID := make(map[int]MediaListEntry, len(after))
+	for _, entry := range after {
+		afterByID[entry.MediaID] = entry
+	}
+
+	var diff ListDiff
+	for _, entry := range after {
+		old, ok := beforeByID[entry.MediaID]
+		if !ok {
+			diff.Added = append(diff.Added, entry)
+			continue
+		}
+		if fields := diffEntryFields(old, entry); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, EntryChange{MediaID: entry.MediaID, Before: old, After: entry, Fields: fields})
+		}
+	}
+	for _, entry := range before {
+		if _, ok := afterByID[entry.MediaID]; !ok {
+			diff.Removed = append(diff.Removed, entry)
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].MediaID < diff.Added[j].MediaID })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].MediaID < diff.Removed[j].MediaID })
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].MediaID < diff.Changed[j].MediaID })
+	return diff
+}
+
+// IsEmpty reports whether both lists were identical.
+func (d ListDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// SyncPlan returns the operations that make the before list match the after
+// list. To make a target account match a source account, diff with the
+// target as before and the source as after, and apply the plan as the target
+// user. Changed entries only send the fields that differ, and a progress
+// that is unset in the after list is saved as 0, since unset fields are not
+// sent.
+func (d ListDiff) SyncPlan() SyncPlan {
+	var plan SyncPlan
+	for _, entry := range d.Added {
+		plan.Save = append(plan.Save, entry.SaveOperation())
+	}
+
+	for _, change := range d.Changed {
+		full := change.After.SaveOperation()
+		op := SaveMediaListEntryOp{MediaID: change.MediaID}
+		for _, field := range change.Fields {
+			switch field.Field {
+			case FieldStatus:
+				op.Status = full.Status
+			case FieldScore:
+				op.Score = full.Score
+			case FieldProgress:
+				op.Progress = intPtrOrZero(full.Progress)
+			case FieldProgressVolumes:
+				op.ProgressVolumes = intPtrOrZero(full.ProgressVolumes)
+			case FieldRepeat:
+				op.Repeat = full.Repeat
+			case FieldNotes:
+				op.Notes = full.Notes
+			case FieldStartedAt:
+				op.StartedAt = full.StartedAt
+			case FieldCompletedAt:
+				op.CompletedAt = full.CompletedAt
+			}
+		}
+		plan.Save = append(plan.Save, op)
+	}
+
+	plan.Delete = append(plan.Delete, d.Removed...)
+	return plan
+}
+
+// SaveOperation returns the operation that saves every field of this entry,
+// for example to copy it to another account.
+func (e MediaListEntry) SaveOperation() SaveMediaListEntryOp {
+	score, repeat, notes := e.Score, e.Repeat, e.Notes
+	startedAt, completedAt := e.StartedAt, e.CompletedAt
+	return SaveMediaListEntryOp{
+		MediaID:         e.MediaID,
+		Status:          e.Status,
+		Score:           &score,
+		Progress:        e.Progress,
+		ProgressVolumes: e.ProgressVolumes,
+		Repeat:          &repeat,
+		Notes:           &notes,
+		StartedAt:       &startedAt,
+		CompletedAt:     &completedAt,
+	}
+}
+
+// ApplySyncPlan saves and deletes the entries of the plan on the
+// authenticated user's list, saves first, like ApplyOperations with the same
+// options. Deletions are recorded in the checkpoint too, by list entry ID, so
+// a sync planned again after an interrupted run resumes the stored plan.
+//
+// Returns:
+//   - The number of saved and deleted entries.
+//   - An error if any operation fails or the context is cancelled.
+func (api *AuthenticatedAPI) ApplySyncPlan(ctx context.Context, plan SyncPlan, options ApplyOptions) (int, error) {
+	return api.applyPlan(ctx, plan, options)
+}
+
+// DeleteMediaListEntry deletes an entry from the authenticated user's list.
+// The id is the ID of the list entry, not of the media.
+func (api *AuthenticatedAPI) DeleteMediaListEntry(id int) error {
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	data, err := sendRequest(BaseAPIURL, DeleteMediaListEntryQuery, variables, api.AccessToken)
+	if err != nil {
+		return err
+	}
+	if data.Data.DeleteMediaListEntry == nil || !data.Data.DeleteMediaListEntry.Deleted {
+		return fmt.Errorf("list entry %d was not deleted", id)
+	}
+	InvalidateLists()
+	return nil
+}
+
+func diffEntryFields(before MediaListEntry, after MediaListEntry) []FieldChange {
+	var fields []FieldChange
+	compare := func(field string, old string, new string) {
+		if old != new {
+			fields = append(fields, FieldChange{Field: field, Old: old, New: new})
+		}
+	}
+
+	compare(FieldStatus, before.Status, after.Status)
+	compare(FieldScore, strconv.Itoa(before.Score), strconv.Itoa(after.Score))
+	compare(FieldProgress, formatIntPtr(before.Progress), formatIntPtr(after.Progress))
+	compare(FieldProgressVolumes, formatIntPtr(before.ProgressVolumes), formatIntPtr(after.ProgressVolumes))
+	compare(FieldRepeat, strconv.Itoa(before.Repeat), strconv.Itoa(after.Repeat))
+	compare(FieldNotes, before.Notes, after.Notes)
+	compare(FieldStartedAt, formatFuzzyDate(before.StartedAt), formatFuzzyDate(after.StartedAt))
+	compare(FieldCompletedAt, formatFuzzyDate(before.CompletedAt), formatFuzzyDate(after.CompletedAt))
+	return fields
+}
+
+func intPtrOrZero(value *int) *int {
+	if value == nil {
+		zero := 0
+		return &zero
+	}
+	return value
+}
+
+func formatIntPtr(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
+func formatFuzzyDate(date FuzzyDate) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.String()
+}
@@ -0,0 +1,125 @@
+package anilistgo
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiffLists(t *testing.T) {
+	five, six := 5, 6
+	before := []MediaListEntry{
+		{ID: 100, MediaID: 1, Status: "CURRENT", Progress: &five, Notes: "Great"},
+		{ID: 101, MediaID: 2, Status: "PLANNING"},
+		{ID: 102, MediaID: 3, Status: "COMPLETED", Score: 80},
+	}
+	after := []MediaListEntry{
+		{ID: 200, MediaID: 1, Status: "CURRENT", Progress: &six, Score: 70},
+		{ID: 201, MediaID: 3, Status: "COMPLETED", Score: 80},
+		{ID: 202, MediaID: 4, Status: "PLANNING"},
+	}
+
+	diff := DiffLists(before, after)
+	if len(diff.Added) != 1 || diff.Added[0].MediaID != 4 {
+		t.Errorf("expected media 4 to be added but got %v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].MediaID != 2 {
+		t.Errorf("expected media 2 to be removed but got %v", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].MediaID != 1 {
+		t.Fatalf("expected media 1 to be changed but got %v", diff.Changed)
+	}
+
+	expected := []FieldChange{
+		{Field: FieldScore, Old: "0", New: "70"},
+		{Field: FieldProgress, Old: "5", New: "6"},
+		{Field: FieldNotes, Old: "Great", New: ""},
+	}
+	fields := diff.Changed[0].Fields
+	if len(fields) != len(expected) {
+		t.Fatalf("expected %d field changes but got %v", len(expected), fields)
+	}
+	for i := range expected {
+		if fields[i] != expected[i] {
+			t.Errorf("expected field change %v but got %v", expected[i], fields[i])
+		}
+	}
+
+	plan := diff.SyncPlan()
+	if len(plan.Save) != 2 || len(plan.Delete) != 1 || plan.Delete[0].ID != 101 {
+		t.Fatalf("expected 2 saves and the deletion of entry 101 but got %+v", plan)
+	}
+	changed := plan.Save[1]
+	if changed.MediaID != 1 || changed.Status != "" || *changed.Progress != 6 || *changed.Score != 70 || *changed.Notes != "" {
+		t.Errorf("expected only the changed fields to be saved but got %+v", changed)
+	}
+
+	if !DiffLists(after, after).IsEmpty() {
+		t.Errorf("expected no differences between identical lists")
+	}
+}
+
+func TestSyncPlanClearsProgress(t *testing.T) {
+	five := 5
+	before := []MediaListEntry{{ID: 100, MediaID: 1, Status: "CURRENT", Progress: &five}}
+	after := []MediaListEntry{{ID: 200, MediaID: 1, Status: "CURRENT"}}
+
+	plan := DiffLists(before, after).SyncPlan()
+	if len(plan.Save) != 1 || plan.Save[0].Progress == nil || *plan.Save[0].Progress != 0 {
+		t.Errorf("expected the removed progress to be saved as 0 but got %+v", plan.Save)
+	}
+}
+
+func TestApplySyncPlanSkipsCheckpointedDeletions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sync.checkpoint")
+	plan := SyncPlan{
+		Save:   []SaveMediaListEntryOp{{MediaID: 1, Status: "CURRENT"}},
+		Delete: []MediaListEntry{{ID: 101, MediaID: 2}},
+	}
+
+	if err := writeApplyCheckpoint(path, applyCheckpoint{Plan: plan, Applied: []int{1}, Deleted: []int{101}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every operation is in the checkpoint, so nothing is sent.
+	applied, err := NewAuthenticatedAPI("token").ApplySyncPlan(context.Background(), plan, ApplyOptions{CheckpointPath: path})
+	if err != nil || applied != 0 {
+		t.Errorf("expected the resumed plan to apply nothing but got %d, %v", applied, err)
+	}
+}
+
+func TestApplySyncPlanResumesAfterReplanning(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sync.checkpoint")
+	options := ApplyOptions{CheckpointPath: path}
+	plan := SyncPlan{
+		Save:   []SaveMediaListEntryOp{{MediaID: 1, Status: "CURRENT"}},
+		Delete: []MediaListEntry{{ID: 101, MediaID: 2}, {ID: 102, MediaID: 3}},
+	}
+
+	var deleted []int
+	failing := true
+	save := func(op SaveMediaListEntryOp) error { return nil }
+	remove := func(entry MediaListEntry) error {
+		if entry.ID == 102 && failing {
+			return errors.New("server error")
+		}
+		deleted = append(deleted, entry.ID)
+		return nil
+	}
+
+	if _, err := applyPlan(context.Background(), plan, options, save, remove); err == nil {
+		t.Fatal("expected the second deletion to fail")
+	}
+
+	// A sync planned again no longer holds the saved and deleted entries.
+	failing = false
+	replanned := SyncPlan{Delete: plan.Delete[1:]}
+	applied, err := applyPlan(context.Background(), replanned, options, save, remove)
+	if err != nil || applied != 1 {
+		t.Fatalf("expected the remaining deletion to be applied but got %d, %v", applied, err)
+	}
+	if len(deleted) != 2 || deleted[0] != 101 || deleted[1] != 102 {
+		t.Errorf("expected every entry to be deleted once but got %v", deleted)
+	}
+}
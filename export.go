@@ -0,0 +1,550 @@
+package anilistgo
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	ColumnUserName        = "user_name"
+	ColumnMediaID         = "media_id"
+	ColumnMediaType       = "media_type"
+	ColumnTitle           = "title"
+	ColumnURL             = "url"
+	ColumnCoverURL        = "cover_url"
+	ColumnStatus          = "status"
+	ColumnUpdatedTime     = "updated_time"
+	ColumnScore           = "score"
+	ColumnProgress        = "progress"
+	ColumnProgressVolumes = "progress_volumes"
+	ColumnTotalEpisodes   = "total_episodes"
+	ColumnTotalVolumes    = "total_volumes"
+	ColumnTotalChapters   = "total_chapters"
+	ColumnRepeat          = "repeat"
+	ColumnPriority        = "priority"
+	ColumnPrivate         = "private"
+	ColumnNotes           = "notes"
+	ColumnStartedAt       = "started_at"
+	ColumnCompletedAt     = "completed_at"
+)
+
+var (
+	// DefaultCSVColumns is the column order used by ExportCSV when no columns
+	// are given. New columns are only ever appended, so the header stays
+	// stable for existing spreadsheets.
+	DefaultCSVColumns = []string{
+		ColumnUserName,
+		ColumnMediaID,
+		ColumnMediaType,
+		ColumnTitle,
+		ColumnURL,
+		ColumnCoverURL,
+		ColumnStatus,
+		ColumnUpdatedTime,
+		ColumnScore,
+		ColumnProgress,
+		ColumnProgressVolumes,
+		ColumnTotalEpisodes,
+		ColumnTotalVolumes,
+		ColumnTotalChapters,
+	}
+
+	// DefaultEntryCSVColumns is the column order used by ExportEntriesCSV when
+	// no columns are given. It holds every field that SaveMediaListEntry
+	// restores, so that a list can be backed up without loss.
+	DefaultEntryCSVColumns = []string{
+		ColumnMediaID,
+		ColumnTitle,
+		ColumnStatus,
+		ColumnScore,
+		ColumnProgress,
+		ColumnProgressVolumes,
+		ColumnRepeat,
+		ColumnNotes,
+		ColumnStartedAt,
+		ColumnCompletedAt,
+		ColumnUpdatedTime,
+	}
+
+	MediaListStatuses = []string{"CURRENT", "PLANNING", "COMPLETED", "DROPPED", "PAUSED", "REPEATING"}
+)
+
+type csvColumn[T any] struct {
+	get func(record *T) string
+	set func(record *T, value string) error
+}
+
+var csvColumns = map[string]csvColumn[Update]{
+	ColumnUserName: {
+		get: func(u *Update) string { return u.UserName },
+		set: func(u *Update, v string) error { u.UserName = v; return nil },
+	},
+	ColumnMediaID: {
+		get: func(u *Update) string { return strconv.Itoa(u.MediaID) },
+		set: func(u *Update, v string) error { return parseCSVInt(v, &u.MediaID) },
+	},
+	ColumnMediaType: {
+		get: func(u *Update) string { return u.MediaType },
+		set: func(u *Update, v string) error { u.MediaType = v; return nil },
+	},
+	ColumnTitle: {
+		get: func(u *Update) string { return u.Title },
+		set: func(u *Update, v string) error { u.Title = v; return nil },
+	},
+	ColumnURL: {
+		get: func(u *Update) string { return u.URL },
+		set: func(u *Update, v string) error { u.URL = v; return nil },
+	},
+	ColumnCoverURL: {
+		get: func(u *Update) string { return u.CoverURL },
+		set: func(u *Update, v string) error { u.CoverURL = v; return nil },
+	},
+	ColumnStatus: {
+		get: func(u *Update) string { return u.Status },
+		set: func(u *Update, v string) error { u.Status = v; return nil },
+	},
+	ColumnUpdatedTime: {
+		get: func(u *Update) string { return strconv.FormatInt(u.UpdatedTime, 10) },
+		set: func(u *Update, v string) error {
+			if v == "" {
+				return nil
+			}
+			value, err := strconv.ParseInt(v, 10, 64)
+			u.UpdatedTime = value
+			return err
+		},
+	},
+	ColumnScore: {
+		get: func(u *Update) string { return strconv.Itoa(u.Score) },
+		set: func(u *Update, v string) error { return parseCSVInt(v, &u.Score) },
+	},
+	ColumnProgress:        intPtrColumn(func(u *Update) **int { return &u.Progress }),
+	ColumnProgressVolumes: intPtrColumn(func(u *Update) **int { return &u.ProgressVol }),
+	ColumnTotalEpisodes:   intPtrColumn(func(u *Update) **int { return &u.TotalEpisodes }),
+	ColumnTotalVolumes:    intPtrColumn(func(u *Update) **int { return &u.TotalVolumes }),
+	ColumnTotalChapters:   intPtrColumn(func(u *Update) **int { return &u.TotalChapters }),
+}
+
+// entryCSVColumns are the columns of ExportEntriesCSV. Title is read from the
+// media of the entry, English first, and imported as its English title.
+var entryCSVColumns = map[string]csvColumn[MediaListEntry]{
+	ColumnMediaID: intColumn(func(e *MediaListEntry) *int { return &e.MediaID }),
+	ColumnTitle: {
+		get: func(e *MediaListEntry) string {
+			if e.Media.Title.English != "" {
+				return e.Media.Title.English
+			}
+			return e.Media.Title.Romaji
+		},
+		set: func(e *MediaListEntry, v string) error { e.Media.Title.English = v; return nil },
+	},
+	ColumnStatus: {
+		get: func(e *MediaListEntry) string { return e.Status },
+		set: func(e *MediaListEntry, v string) error { e.Status = v; return nil },
+	},
+	ColumnUpdatedTime: {
+		get: func(e *MediaListEntry) string { return strconv.FormatInt(e.UpdatedAt, 10) },
+		set: func(e *MediaListEntry, v string) error {
+			if v == "" {
+				return nil
+			}
+			value, err := strconv.ParseInt(v, 10, 64)
+			e.UpdatedAt = value
+			return err
+		},
+	},
+	ColumnScore:           intColumn(func(e *MediaListEntry) *int { return &e.Score }),
+	ColumnProgress:        intPtrColumn(func(e *MediaListEntry) **int { return &e.Progress }),
+	ColumnProgressVolumes: intPtrColumn(func(e *MediaListEntry) **int { return &e.ProgressVolumes }),
+	ColumnRepeat:          intColumn(func(e *MediaListEntry) *int { return &e.Repeat }),
+	ColumnPriority:        intColumn(func(e *MediaListEntry) *int { return &e.Priority }),
+	ColumnPrivate: {
+		get: func(e *MediaListEntry) string { return strconv.FormatBool(e.Private) },
+		set: func(e *MediaListEntry, v string) error {
+			if v == "" {
+				e.Private = false
+				return nil
+			}
+			value, err := strconv.ParseBool(v)
+			e.Private = value
+			return err
+		},
+	},
+	ColumnNotes: {
+		get: func(e *MediaListEntry) string { return e.Notes },
+		set: func(e *MediaListEntry, v string) error { e.Notes = v; return nil },
+	},
+	ColumnStartedAt:   fuzzyDateColumn(func(e *MediaListEntry) *FuzzyDate { return &e.StartedAt }),
+	ColumnCompletedAt: fuzzyDateColumn(func(e *MediaListEntry) *FuzzyDate { return &e.CompletedAt }),
+}
+
+// ExportCSV writes the updates as CSV with a header row. Nil pointer fields,
+// such as an unknown number of episodes, are written as empty cells.
+//
+// Parameters:
+//   - w: The writer to write the CSV to.
+//   - updates: The updates to export. Entries from GetMediaListCollection are
+//     exported with ExportEntriesCSV, which keeps their notes, dates and
+//     repeat counts.
+//   - columns: The columns to write, in order, using the Column constants.
+//     DefaultCSVColumns is used if it is empty.
+//
+// Usage:
+//
+//	updates, err := GetUpdates("Ithilias", MediaTypeAnime, nil, nil)
+//	err = ExportCSV(file, updates, []string{ColumnTitle, ColumnStatus, ColumnProgress})
+func ExportCSV(w io.Writer, updates []Update, columns []string) error {
+	if len(columns) == 0 {
+		columns = DefaultCSVColumns
+	}
+	return writeCSV(w, csvColumns, updates, columns)
+}
+
+// ExportEntriesCSV writes list entries, such as those of
+// GetMediaListCollection, as CSV with a header row, like ExportCSV. Dates are
+// written as YYYY-MM-DD with unknown parts left empty, so 2024 is a date of
+// which only the year is known and -04-07 one of which the year is unknown.
+//
+// Parameters:
+//   - w: The writer to write the CSV to.
+//   - entries: The entries to export.
+//   - columns: The columns to write, in order, using the Column constants.
+//     DefaultEntryCSVColumns is used if it is empty.
+//
+// Usage:
+//
+//	entries, err := GetMediaListCollection("Ithilias", MediaTypeAnime)
+//	err = ExportEntriesCSV(file, entries, nil)
+func ExportEntriesCSV(w io.Writer, entries []MediaListEntry, columns []string) error {
+	if len(columns) == 0 {
+		columns = DefaultEntryCSVColumns
+	}
+	return writeCSV(w, entryCSVColumns, entries, columns)
+}
+
+func writeCSV[T any](w io.Writer, known map[string]csvColumn[T], records []T, columns []string) error {
+	definitions := make([]csvColumn[T], len(columns))
+	for i, name := range columns {
+		column, ok := known[name]
+		if !ok {
+			return fmt.Errorf("unknown CSV column: %s", name)
+		}
+		definitions[i] = column
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+
+	record := make([]string, len(columns))
+	for i := range records {
+		for j, column := range definitions {
+			record[j] = column.get(&records[i])
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ImportCSV reads updates written by ExportCSV. The columns are matched by the
+// header row, so any subset and order of the known columns is accepted, but
+// media_id is required. Every row is validated: numbers must parse, and the
+// media type and status must be empty or valid AniList values.
+//
+// Returns:
+//   - The imported updates, where empty cells are nil pointer fields.
+//   - An error naming the line of the first invalid row.
+func ImportCSV(r io.Reader) ([]Update, error) {
+	return readCSV(r, csvColumns, validateUpdate)
+}
+
+// ImportEntriesCSV reads list entries written by ExportEntriesCSV, matching
+// and validating the columns like ImportCSV. The entries can be restored with
+// EntriesToOperations.
+func ImportEntriesCSV(r io.Reader) ([]MediaListEntry, error) {
+	return readCSV(r, entryCSVColumns, validateEntry)
+}
+
+func readCSV[T any](r io.Reader, known map[string]csvColumn[T], validate func(record T) error) ([]T, error) {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := make([]csvColumn[T], len(header))
+	hasMediaID := false
+	for i, name := range header {
+		column, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown CSV column: %s", name)
+		}
+		definitions[i] = column
+		hasMediaID = hasMediaID || name == ColumnMediaID
+	}
+	if !hasMediaID {
+		return nil, fmt.Errorf("missing required CSV column: %s", ColumnMediaID)
+	}
+
+	var records []T
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		var record T
+		for i, column := range definitions {
+			if err := column.set(&record, fields[i]); err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s %q: %w", line, header[i], fields[i], err)
+			}
+		}
+		if err := validate(record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		records = append(records, record)
+	}
+}
+
+// updateRecord is an Update as written by WriteJSONLines, with the names of
+// the CSV columns of ExportCSV.
+type updateRecord struct {
+	UserName      string `json:"user_name"`
+	MediaID       int    `json:"media_id"`
+	Title         string `json:"title"`
+	URL           string `json:"url"`
+	CoverURL      string `json:"cover_url"`
+	Status        string `json:"status"`
+	UpdatedTime   int64  `json:"updated_time"`
+	Score         int    `json:"score"`
+	Progress      *int   `json:"progress"`
+	ProgressVol   *int   `json:"progress_volumes"`
+	TotalEpisodes *int   `json:"total_episodes"`
+	TotalVolumes  *int   `json:"total_volumes"`
+	TotalChapters *int   `json:"total_chapters"`
+	Duration      *int   `json:"duration"`
+	MediaType     string `json:"media_type"`
+}
+
+// jsonLinesValue returns the value to encode or decode for a record, which
+// is the record itself unless it is an Update.
+func jsonLinesValue(record any) any {
+	if update, ok := record.(*Update); ok {
+		return (*updateRecord)(update)
+	}
+	return record
+}
+
+// WriteJSONLines writes every record as one line of JSON. It works with any
+// JSON serializable type, such as MediaListEntry, which keeps every field of
+// a list entry. Updates are written with the names of the CSV columns.
+func WriteJSONLines[T any](w io.Writer, records []T) error {
+	bw := bufio.NewWriter(w)
+	encoder := json.NewEncoder(bw)
+	for i := range records {
+		if err := encoder.Encode(jsonLinesValue(&records[i])); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadJSONLines reads records written by WriteJSONLines, skipping empty
+// lines.
+//
+// Usage:
+//
+//	updates, err := ReadJSONLines[Update](file)
+func ReadJSONLines[T any](r io.Reader) ([]T, error) {
+	var records []T
+	decoder := json.NewDecoder(r)
+	for line := 1; ; line++ {
+		var record T
+		err := decoder.Decode(jsonLinesValue(&record))
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+}
+
+// UpdatesToOperations validates the updates and converts them into operations
+// that can be applied with AuthenticatedAPI.ApplyOperations, for example to
+// restore a list from a CSV or JSON Lines export.
+func UpdatesToOperations(updates []Update) ([]SaveMediaListEntryOp, error) {
+	ops := make([]SaveMediaListEntryOp, 0, len(updates))
+	for i, update := range updates {
+		if err := validateUpdate(update); err != nil {
+			return nil, fmt.Errorf("update %d: %w", i+1, err)
+		}
+		ops = append(ops, update.SaveOperation())
+	}
+	return ops, nil
+}
+
+// EntriesToOperations validates the entries and converts them into operations
+// that can be applied with AuthenticatedAPI.ApplyOperations, for example to
+// restore a list from ImportEntriesCSV or ReadJSONLines[MediaListEntry].
+func EntriesToOperations(entries []MediaListEntry) ([]SaveMediaListEntryOp, error) {
+	ops := make([]SaveMediaListEntryOp, 0, len(entries))
+	for i, entry := range entries {
+		if err := validateEntry(entry); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		ops = append(ops, entry.SaveOperation())
+	}
+	return ops, nil
+}
+
+// SaveOperation returns the operation that saves this update on AniList. The
+// score is only included if the update has one.
+func (u Update) SaveOperation() SaveMediaListEntryOp {
+	op := SaveMediaListEntryOp{
+		MediaID:         u.MediaID,
+		Status:          u.Status,
+		Progress:        u.Progress,
+		ProgressVolumes: u.ProgressVol,
+	}
+	if u.Score > 0 {
+		score := u.Score
+		op.Score = &score
+	}
+	return op
+}
+
+func validateUpdate(update Update) error {
+	if update.MediaID <= 0 {
+		return fmt.Errorf("invalid media ID: %d", update.MediaID)
+	}
+	if update.MediaType != "" && update.MediaType != MediaTypeAnime && update.MediaType != MediaTypeManga {
+		return fmt.Errorf("invalid mediaType provided: %s. Accepts only %s or %s", update.MediaType, MediaTypeAnime, MediaTypeManga)
+	}
+	if update.Status != "" && !isValidListStatus(update.Status) {
+		return fmt.Errorf("invalid status: %s", update.Status)
+	}
+	if update.Score < 0 || update.Score > 100 {
+		return fmt.Errorf("invalid score: %d", update.Score)
+	}
+	return nil
+}
+
+func validateEntry(entry MediaListEntry) error {
+	return validateUpdate(Update{MediaID: entry.MediaID, Status: entry.Status, Score: entry.Score})
+}
+
+func isValidListStatus(status string) bool {
+	for _, s := range MediaListStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func intColumn[T any](field func(record *T) *int) csvColumn[T] {
+	return csvColumn[T]{
+		get: func(record *T) string { return strconv.Itoa(*field(record)) },
+		set: func(record *T, v string) error { return parseCSVInt(v, field(record)) },
+	}
+}
+
+func intPtrColumn[T any](field func(record *T) **int) csvColumn[T] {
+	return csvColumn[T]{
+		get: func(record *T) string {
+			value := *field(record)
+			if value == nil {
+				return ""
+			}
+			return strconv.Itoa(*value)
+		},
+		set: func(record *T, v string) error {
+			if v == "" {
+				*field(record) = nil
+				return nil
+			}
+			value, err := strconv.Atoi(v)
+			if err != nil {
+				return err
+			}
+			*field(record) = &value
+			return nil
+		},
+	}
+}
+
+// fuzzyDateColumn writes a date as YYYY-MM-DD, leaving unknown parts empty
+// and dropping those at the end, so 2024 and -04-07 are dates of which only
+// the year or the month and day are known.
+func fuzzyDateColumn[T any](field func(record *T) *FuzzyDate) csvColumn[T] {
+	return csvColumn[T]{
+		get: func(record *T) string {
+			date := field(record)
+			parts := make([]string, 3)
+			for i, part := range []*int{date.Year, date.Month, date.Day} {
+				if part == nil {
+					continue
+				}
+				if i == 0 {
+					parts[i] = fmt.Sprintf("%04d", *part)
+				} else {
+					parts[i] = fmt.Sprintf("%02d", *part)
+				}
+			}
+			for len(parts) > 0 && parts[len(parts)-1] == "" {
+				parts = parts[:len(parts)-1]
+			}
+			return strings.Join(parts, "-")
+		},
+		set: func(record *T, v string) error {
+			date := FuzzyDate{}
+			if v != "" {
+				parts := strings.Split(v, "-")
+				if len(parts) > 3 {
+					return fmt.Errorf("expected YYYY-MM-DD")
+				}
+				targets := []**int{&date.Year, &date.Month, &date.Day}
+				for i, part := range parts {
+					if part == "" {
+						continue
+					}
+					value, err := strconv.Atoi(part)
+					if err != nil {
+						return err
+					}
+					*targets[i] = &value
+				}
+			}
+			*field(record) = date
+			return nil
+		},
+	}
+}
+
+func parseCSVInt(value string, target *int) error {
+	if value == "" {
+		*target = 0
+		return nil
+	}
+	parsed, err := strconv.Atoi(value)
+	*target = parsed
+	return err
+}
@@ -0,0 +1,170 @@
+package anilistgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	five, twelve := 5, 12
+	updates := []Update{
+		{UserName: "Ithilias", MediaID: 21, MediaType: MediaTypeAnime, Title: "One Piece, \"the\" anime", Status: "CURRENT", UpdatedTime: 100, Score: 90, Progress: &five},
+		{UserName: "Ithilias", MediaID: 30, MediaType: MediaTypeManga, Title: "Berserk", Status: "PAUSED", Progress: &five, ProgressVol: &five, TotalChapters: &twelve},
+	}
+
+	var buf bytes.Buffer
+	if err := ExportCSV(&buf, updates, nil); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if header := strings.SplitN(buf.String(), "\n", 2)[0]; header != strings.Join(DefaultCSVColumns, ",") {
+		t.Errorf("expected default header but got %q", header)
+	}
+
+	imported, err := ImportCSV(&buf)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if !reflect.DeepEqual(imported, updates) {
+		t.Errorf("expected updates to round-trip but got %+v", imported)
+	}
+	if imported[0].TotalEpisodes != nil || imported[1].TotalVolumes != nil {
+		t.Errorf("expected empty cells to be nil")
+	}
+}
+
+func TestExportCSVColumns(t *testing.T) {
+	five := 5
+	var buf bytes.Buffer
+	err := ExportCSV(&buf, []Update{{MediaID: 21, Progress: &five}}, []string{ColumnMediaID, ColumnProgress, ColumnTotalEpisodes})
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if buf.String() != "media_id,progress,total_episodes\n21,5,\n" {
+		t.Errorf("expected selected columns but got %q", buf.String())
+	}
+
+	if err := ExportCSV(&buf, nil, []string{"unknown"}); err == nil {
+		t.Errorf("expected an error for an unknown column but got none")
+	}
+}
+
+func TestImportCSVValidation(t *testing.T) {
+	tests := []string{
+		"title\nOne Piece\n",
+		"media_id,status\n21,WATCHING\n",
+		"media_id,progress\n21,five\n",
+		"media_id,media_type\n0,ANIME\n",
+	}
+
+	for _, input := range tests {
+		if _, err := ImportCSV(strings.NewReader(input)); err == nil {
+			t.Errorf("expected an error for %q but got none", input)
+		}
+	}
+}
+
+func TestJSONLinesRoundTrip(t *testing.T) {
+	five := 5
+	updates := []Update{{MediaID: 21, Progress: &five}, {MediaID: 30}}
+
+	var buf bytes.Buffer
+	if err := WriteJSONLines(&buf, updates); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if strings.Count(buf.String(), "\n") != 2 {
+		t.Errorf("expected one line per record but got %q", buf.String())
+	}
+
+	imported, err := ReadJSONLines[Update](&buf)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if !reflect.DeepEqual(imported, updates) {
+		t.Errorf("expected updates to round-trip but got %+v", imported)
+	}
+
+	ops, err := UpdatesToOperations(imported)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if len(ops) != 2 || *ops[0].Progress != 5 || ops[1].Progress != nil || ops[0].Score != nil {
+		t.Errorf("expected operations to keep nil fields but got %+v", ops)
+	}
+}
+
+func TestEntriesCSVRoundTrip(t *testing.T) {
+	five, year, month, day, april, seventh := 5, 2024, 3, 9, 4, 7
+	entries := []MediaListEntry{
+		{
+			MediaID:     21,
+			Status:      "REPEATING",
+			Score:       90,
+			Progress:    &five,
+			Repeat:      2,
+			Notes:       "Rewatching, \"again\"",
+			StartedAt:   FuzzyDate{Year: &year, Month: &month, Day: &day},
+			CompletedAt: FuzzyDate{Year: &year},
+			UpdatedAt:   100,
+			Media:       Media{Title: MediaTitle{English: "One Piece"}},
+		},
+		{
+			MediaID:     30,
+			Status:      "PLANNING",
+			StartedAt:   FuzzyDate{Month: &april, Day: &seventh},
+			CompletedAt: FuzzyDate{Year: &year, Day: &seventh},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := ExportEntriesCSV(&buf, entries, nil); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	for _, dates := range []string{",2024-03-09,2024,", ",-04-07,2024--07,"} {
+		if !strings.Contains(buf.String(), dates) {
+			t.Errorf("expected the dates %s in %q", dates, buf.String())
+		}
+	}
+
+	imported, err := ImportEntriesCSV(&buf)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if !reflect.DeepEqual(imported, entries) {
+		t.Errorf("expected entries to round-trip but got %+v", imported)
+	}
+
+	ops, err := EntriesToOperations(imported)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if *ops[0].Repeat != 2 || *ops[0].Notes != entries[0].Notes || *ops[0].StartedAt.Day != 9 || ops[0].CompletedAt.Month != nil {
+		t.Errorf("expected operations to keep notes, dates and repeat but got %+v", ops[0])
+	}
+
+	if _, err := ImportEntriesCSV(strings.NewReader("media_id,started_at\n21,2024-x\n")); err == nil {
+		t.Error("expected an error for an invalid date")
+	}
+}
+
+func TestUpdateJSONNamesMatchCSVColumns(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSONLines(&buf, []Update{{}}); err != nil {
+		t.Fatal(err)
+	}
+	for _, column := range DefaultCSVColumns {
+		if !strings.Contains(buf.String(), `"`+column+`":`) {
+			t.Errorf("expected the JSON name %s in %s", column, buf.String())
+		}
+	}
+
+	data, err := json.Marshal(Update{MediaID: 21})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"MediaID":21`) {
+		t.Errorf("expected Update to marshal with its field names but got %s", data)
+	}
+}
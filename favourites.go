@@ -0,0 +1,485 @@
+package anilistgo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+const (
+	FavouriteAnime     = "anime"
+	FavouriteManga     = "manga"
+	FavouriteCharacter = "character"
+	FavouriteStaff     = "staff"
+	FavouriteStudio    = "studio"
+
+	FavouriteAnimeQuery = `
+    query ($name: String, $page: Int, $perPage: Int) {
+      User (name: $name) {
+        id
+        favourites {
+          anime (page: $page, perPage: $perPage) {
+            pageInfo {
+              total
+              perPage
+              currentPage
+              lastPage
+              hasNextPage
+            }
+            edges {
+              favouriteOrder
+              node {
+                id
+                type
+                format
+                title {
+                  romaji
+                  english
+                  native
+                }
+                coverImage {
+                  extraLarge
+                }
+                siteUrl
+              }
+            }
+          }
+        }
+      }
+    }
+    `
+
+	FavouriteMangaQuery = `
+    query ($name: String, $page: Int, $perPage: Int) {
+      User (name: $name) {
+        id
+        favourites {
+          manga (page: $page, perPage: $perPage) {
+            pageInfo {
+              total
+              perPage
+              currentPage
+              lastPage
+              hasNextPage
+            }
+            edges {
+              favouriteOrder
+              node {
+                id
+                type
+                format
+                title {
+                  romaji
+                  english
+                  native
+                }
+                coverImage {
+                  extraLarge
+                }
+                siteUrl
+              }
+            }
+          }
+        }
+      }
+    }
+    `
+
+	FavouriteCharactersQuery = `
+    query ($name: String, $page: Int, $perPage: Int) {
+      User (name: $name) {
+        id
+        favourites {
+          characters (page: $page, perPage: $perPage) {
+            pageInfo {
+              total
+              perPage
+              currentPage
+              lastPage
+              hasNextPage
+            }
+            edges {
+              favouriteOrder
+              node {
+                id
+                name {
+                  full
+                  native
+                }
+                image {
+                  large
+                  medium
+                }
+                siteUrl
+              }
+            }
+          }
+        }
+      }
+    }
+    `
+
+	FavouriteStaffQuery = `
+    query ($name: String, $page: Int, $perPage: Int) {
+      User (name: $name) {
+        id
+        favourites {
+          staff (page: $page, perPage: $perPage) {
+            pageInfo {
+              total
+              perPage
+              currentPage
+              lastPage
+              hasNextPage
+            }
+            edges {
+              favouriteOrder
+              node {
+                id
+                name {
+                  full
+                  native
+                }
+                image {
+                  large
+                  medium
+                }
+                siteUrl
+              }
+            }
+          }
+        }
+      }
+    }
+    `
+
+	FavouriteStudiosQuery = `
+    query ($name: String, $page: Int, $perPage: Int) {
+      User (name: $name) {
+        id
+        favourites {
+          studios (page: $page, perPage: $perPage) {
+            pageInfo {
+              total
+              perPage
+              currentPage
+              lastPage
+              hasNextPage
+            }
+            edges {
+              favouriteOrder
+              node {
+                id
+                name
+                siteUrl
+              }
+            }
+          }
+        }
+      }
+    }
+    `
+
+	ToggleFavouriteQuery = `
+    mutation ($animeId: Int, $mangaId: Int, $characterId: Int, $staffId: Int, $studioId: Int) {
+      ToggleFavourite (animeId: $animeId, mangaId: $mangaId, characterId: $characterId, staffId: $staffId, studioId: $studioId) {
+        anime {
+          pageInfo {
+            total
+          }
+        }
+      }
+    }
+    `
+
+	// viewerFavouriteIDsQueryFormat is formatted with the field of a kind of
+	// favourite, such as characters.
+	viewerFavouriteIDsQueryFormat = `
+    query ($page: Int, $perPage: Int) {
+      Viewer {
+        favourites {
+          %s (page: $page, perPage: $perPage) {
+            pageInfo {
+              hasNextPage
+            }
+            edges {
+              favouriteOrder
+              node {
+                id
+              }
+            }
+          }
+        }
+      }
+    }
+    `
+
+	UpdateFavouriteOrderQuery = `
+    mutation ($animeIds: [Int], $animeOrder: [Int], $mangaIds: [Int], $mangaOrder: [Int], $characterIds: [Int], $characterOrder: [Int], $staffIds: [Int], $staffOrder: [Int], $studioIds: [Int], $studioOrder: [Int]) {
+      UpdateFavouriteOrder (animeIds: $animeIds, animeOrder: $animeOrder, mangaIds: $mangaIds, mangaOrder: $mangaOrder, characterIds: $characterIds, characterOrder: $characterOrder, staffIds: $staffIds, staffOrder: $staffOrder, studioIds: $studioIds, studioOrder: $studioOrder) {
+        anime {
+          pageInfo {
+            total
+          }
+        }
+      }
+    }
+    `
+)
+
+// GetFavouriteAnime retrieves one page of a user's favourite anime, in the
+// order chosen by the user.
+//
+// Parameters:
+//   - name: The AniList username.
+//   - page: The page to fetch, starting at 1.
+//   - perPage: The number of favourites per page, at most 50.
+//
+// Returns:
+//   - A slice of FavouriteMediaEdge structs, where Node is the anime and
+//     FavouriteOrder its position.
+//   - The PageInfo of the fetched page, to know whether there is a next page.
+//   - An error if there's any issue fetching the data or the user does not
+//     exist.
+//
+// Usage:
+//
+//	favourites, err := CollectPages(func(page int) ([]FavouriteMediaEdge, PageInfo, error) {
+//	    return GetFavouriteAnime("Ithilias", page, MaxPerPage)
+//	})
+func GetFavouriteAnime(name string, page int, perPage int) ([]FavouriteMediaEdge, PageInfo, error) {
+	favourites, err := fetchFavourites(FavouriteAnimeQuery, name, page, perPage)
+	if err != nil || favourites.Anime == nil {
+		return nil, PageInfo{}, err
+	}
+	edges := favourites.Anime.Edges
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].FavouriteOrder < edges[j].FavouriteOrder })
+	return edges, favourites.Anime.PageInfo, nil
+}
+
+// GetFavouriteManga retrieves one page of a user's favourite manga, in the
+// order chosen by the user.
+func GetFavouriteManga(name string, page int, perPage int) ([]FavouriteMediaEdge, PageInfo, error) {
+	favourites, err := fetchFavourites(FavouriteMangaQuery, name, page, perPage)
+	if err != nil || favourites.Manga == nil {
+		return nil, PageInfo{}, err
+	}
+	edges := favourites.Manga.Edges
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].FavouriteOrder < edges[j].FavouriteOrder })
+	return edges, favourites.Manga.PageInfo, nil
+}
+
+// GetFavouriteCharacters retrieves one page of a user's favourite
+// characters, in the order chosen by the user.
+func GetFavouriteCharacters(name string, page int, perPage int) ([]CharacterEdge, PageInfo, error) {
+	favourites, err := fetchFavourites(FavouriteCharactersQuery, name, page, perPage)
+	if err != nil || favourites.Characters == nil {
+		return nil, PageInfo{}, err
+	}
+	edges := favourites.Characters.Edges
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].FavouriteOrder < edges[j].FavouriteOrder })
+	return edges, favourites.Characters.PageInfo, nil
+}
+
+// GetFavouriteStaff retrieves one page of a user's favourite staff members,
+// in the order chosen by the user.
+func GetFavouriteStaff(name string, page int, perPage int) ([]StaffEdge, PageInfo, error) {
+	favourites, err := fetchFavourites(FavouriteStaffQuery, name, page, perPage)
+	if err != nil || favourites.Staff == nil {
+		return nil, PageInfo{}, err
+	}
+	edges := favourites.Staff.Edges
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].FavouriteOrder < edges[j].FavouriteOrder })
+	return edges, favourites.Staff.PageInfo, nil
+}
+
+// GetFavouriteStudios retrieves one page of a user's favourite studios, in
+// the order chosen by the user.
+func GetFavouriteStudios(name string, page int, perPage int) ([]StudioEdge, PageInfo, error) {
+	favourites, err := fetchFavourites(FavouriteStudiosQuery, name, page, perPage)
+	if err != nil || favourites.Studios == nil {
+		return nil, PageInfo{}, err
+	}
+	edges := favourites.Studios.Edges
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].FavouriteOrder < edges[j].FavouriteOrder })
+	return edges, favourites.Studios.PageInfo, nil
+}
+
+// ToggleFavourite adds an anime, manga, character, staff member or studio to
+// the authenticated user's favourites, or removes it if it already is one.
+//
+// Parameters:
+//   - kind: One of the Favourite constants, such as FavouriteCharacter.
+//   - id: The AniList ID of the favourite.
+//
+// Usage:
+//
+//	err := api.ToggleFavourite(FavouriteCharacter, 40)
+func (api *AuthenticatedAPI) ToggleFavourite(kind string, id int) error {
+	if !isValidFavouriteKind(kind) {
+		return fmt.Errorf("invalid favourite kind: %s", kind)
+	}
+
+	variables := map[string]interface{}{
+		kind + "Id": id,
+	}
+
+	_, err := sendRequest(BaseAPIURL, ToggleFavouriteQuery, variables, api.AccessToken)
+	return err
+}
+
+// ReorderFavourites sets the order of the authenticated user's favourites of
+// one kind. The ids are the AniList IDs of the favourites, first to last, and
+// take the positions they hold among the user's favourites, which are fetched
+// first. Favourites that are left out keep their position, so reordering
+// {A, B, C, D} with {D, B} results in {A, D, C, B}.
+//
+// Returns:
+//   - An error if kind is invalid, an ID is not one of the user's favourites
+//     or is given twice, or there's any issue with the requests.
+//
+// Usage:
+//
+//	err := api.ReorderFavourites(FavouriteAnime, []int{20, 21, 1535})
+func (api *AuthenticatedAPI) ReorderFavourites(kind string, ids []int) error {
+	return reorderFavourites(BaseAPIURL, api.AccessToken, kind, ids)
+}
+
+func reorderFavourites(url string, accessToken string, kind string, ids []int) error {
+	if !isValidFavouriteKind(kind) {
+		return fmt.Errorf("invalid favourite kind: %s", kind)
+	}
+
+	current, err := fetchViewerFavouriteIDs(url, accessToken, kind)
+	if err != nil {
+		return err
+	}
+	order, err := mergeFavouriteOrder(current, ids)
+	if err != nil {
+		return err
+	}
+
+	variables, err := favouriteOrderVariables(kind, order)
+	if err != nil {
+		return err
+	}
+	_, err = sendRequest(url, UpdateFavouriteOrderQuery, variables, accessToken)
+	return err
+}
+
+// fetchViewerFavouriteIDs returns the IDs of the authenticated user's
+// favourites of one kind, in their order.
+func fetchViewerFavouriteIDs(url string, accessToken string, kind string) ([]int, error) {
+	field := kind
+	if kind == FavouriteCharacter || kind == FavouriteStudio {
+		field += "s"
+	}
+	query := fmt.Sprintf(viewerFavouriteIDsQueryFormat, field)
+
+	type idEdge struct {
+		FavouriteOrder int `json:"favouriteOrder"`
+		Node           struct {
+			ID int `json:"id"`
+		} `json:"node"`
+	}
+	edges, err := CollectPages(func(page int) ([]idEdge, PageInfo, error) {
+		var data struct {
+			Viewer struct {
+				Favourites map[string]struct {
+					PageInfo PageInfo `json:"pageInfo"`
+					Edges    []idEdge `json:"edges"`
+				} `json:"favourites"`
+			} `json:"Viewer"`
+		}
+		variables := map[string]interface{}{"page": page, "perPage": MaxPerPage}
+		if err := runQuery(context.Background(), url, query, variables, &data, accessToken); err != nil {
+			return nil, PageInfo{}, err
+		}
+		connection := data.Viewer.Favourites[field]
+		return connection.Edges, connection.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].FavouriteOrder < edges[j].FavouriteOrder })
+	ids := make([]int, len(edges))
+	for i, edge := range edges {
+		ids[i] = edge.Node.ID
+	}
+	return ids, nil
+}
+
+// mergeFavouriteOrder returns the order of the favourites current after
+// reordering ids, which take the positions they hold in current.
+func mergeFavouriteOrder(current []int, ids []int) ([]int, error) {
+	positions := make(map[int]int, len(current))
+	for i, id := range current {
+		positions[id] = i
+	}
+
+	slots := make([]int, 0, len(ids))
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		position, ok := positions[id]
+		if !ok {
+			return nil, fmt.Errorf("%d is not a favourite", id)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("favourite %d is given twice", id)
+		}
+		seen[id] = true
+		slots = append(slots, position)
+	}
+	sort.Ints(slots)
+
+	order := append([]int(nil), current...)
+	for i, slot := range slots {
+		order[slot] = ids[i]
+	}
+	return order, nil
+}
+
+func fetchFavourites(query string, name string, page int, perPage int) (*Favourites, error) {
+	variables := map[string]interface{}{
+		"name":    name,
+		"page":    page,
+		"perPage": perPage,
+	}
+
+	data, err := sendRequest(BaseAPIURL, query, variables, "")
+	if err != nil {
+		return nil, err
+	}
+	if data.Data.User.ID == 0 {
+		return nil, fmt.Errorf("user %s not found", name)
+	}
+	if data.Data.User.Favourites == nil {
+		return &Favourites{}, nil
+	}
+	return data.Data.User.Favourites, nil
+}
+
+func favouriteOrderVariables(kind string, ids []int) (map[string]interface{}, error) {
+	if !isValidFavouriteKind(kind) {
+		return nil, fmt.Errorf("invalid favourite kind: %s", kind)
+	}
+
+	order := make([]int, len(ids))
+	for i := range ids {
+		order[i] = i + 1
+	}
+
+	return map[string]interface{}{
+		kind + "Ids":   ids,
+		kind + "Order": order,
+	}, nil
+}
+
+func isValidFavouriteKind(kind string) bool {
+	switch kind {
+	case FavouriteAnime, FavouriteManga, FavouriteCharacter, FavouriteStaff, FavouriteStudio:
+		return true
+	}
+	return false
+}
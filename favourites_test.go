@@ -0,0 +1,87 @@
+package anilistgo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFavouriteOrderVariables(t *testing.T) {
+	variables, err := favouriteOrderVariables(FavouriteCharacter, []int{40, 17, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"characterIds":   []int{40, 17, 1},
+		"characterOrder": []int{1, 2, 3},
+	}
+	if !reflect.DeepEqual(variables, expected) {
+		t.Errorf("expected %v but got %v", expected, variables)
+	}
+
+	if _, err := favouriteOrderVariables("media", []int{1}); err == nil {
+		t.Error("expected an error for an invalid favourite kind")
+	}
+}
+
+func TestMergeFavouriteOrder(t *testing.T) {
+	order, err := mergeFavouriteOrder([]int{1, 2, 3, 4}, []int{4, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []int{1, 4, 3, 2}; !reflect.DeepEqual(order, expected) {
+		t.Errorf("expected %v but got %v", expected, order)
+	}
+
+	if _, err := mergeFavouriteOrder([]int{1, 2}, []int{3}); err == nil {
+		t.Error("expected an error for an ID that is not a favourite")
+	}
+	if _, err := mergeFavouriteOrder([]int{1, 2}, []int{1, 1}); err == nil {
+		t.Error("expected an error for an ID given twice")
+	}
+}
+
+func TestReorderFavouritesKeepsOmittedFavourites(t *testing.T) {
+	var mutation map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("invalid request: %v", err)
+		}
+		if strings.Contains(request.Query, "UpdateFavouriteOrder") {
+			mutation = request.Variables
+			w.Write([]byte(`{"data": {"UpdateFavouriteOrder": {"anime": {"pageInfo": {"total": 3}}}}}`))
+			return
+		}
+		if !strings.Contains(request.Query, "characters (page") {
+			t.Errorf("expected a query of the favourite characters but got %s", request.Query)
+		}
+		w.Write([]byte(`{"data": {"Viewer": {"favourites": {"characters": {
+			"pageInfo": {"hasNextPage": false},
+			"edges": [
+				{"favouriteOrder": 2, "node": {"id": 17}},
+				{"favouriteOrder": 1, "node": {"id": 40}},
+				{"favouriteOrder": 3, "node": {"id": 1}}
+			]
+		}}}}}`))
+	}))
+	defer server.Close()
+
+	if err := reorderFavourites(server.URL, "token", FavouriteCharacter, []int{1, 40}); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"characterIds":   []interface{}{float64(1), float64(17), float64(40)},
+		"characterOrder": []interface{}{float64(1), float64(2), float64(3)},
+	}
+	if !reflect.DeepEqual(mutation, expected) {
+		t.Errorf("expected the variables %v but got %v", expected, mutation)
+	}
+}
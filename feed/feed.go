@@ -0,0 +1,339 @@
+// Package feed turns AniList list updates into RSS 2.0 and Atom 1.0 feeds.
+package feed
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Ithilias/anilistgo"
+)
+
+const (
+	FormatRSS  = "rss"
+	FormatAtom = "atom"
+
+	RSSContentType  = "application/rss+xml; charset=utf-8"
+	AtomContentType = "application/atom+xml; charset=utf-8"
+)
+
+// Options describes the feed itself. Link is the page the feed is about, such
+// as the AniList profile of the user.
+type Options struct {
+	Title       string
+	Link        string
+	Description string
+}
+
+// Handler serves a feed of the updates returned by Source, in RSS or Atom
+// format depending on Format. It supports conditional GET requests using the
+// ETag and Last-Modified headers.
+type Handler struct {
+	Options Options
+	Format  string
+	Source  func() ([]anilistgo.Update, error)
+}
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link,omitempty"`
+	Description string        `xml:"description"`
+	GUID        rssGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate"`
+	Enclosure   *rssEnclosure `xml:"enclosure,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int    `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title    string      `xml:"title"`
+	ID       string      `xml:"id"`
+	Updated  string      `xml:"updated"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Author   atomAuthor  `xml:"author"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Summary string      `xml:"summary"`
+	Author  *atomAuthor `xml:"author,omitempty"`
+	Links   []atomLink  `xml:"link"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// ProgressSummary describes the progress of an update in a short sentence,
+// such as "Watched episode 5 of 12" or "Read chapter 30".
+func ProgressSummary(update anilistgo.Update) string {
+	verb, unit, total := "Watched", "episode", update.TotalEpisodes
+	planned := "Plans to watch"
+	if update.MediaType == anilistgo.MediaTypeManga {
+		verb, unit, total = "Read", "chapter", update.TotalChapters
+		planned = "Plans to read"
+	}
+
+	progress := 0
+	if update.Progress != nil {
+		progress = *update.Progress
+	}
+
+	position := fmt.Sprintf("%s %d", unit, progress)
+	if total != nil && *total > 0 {
+		position = fmt.Sprintf("%s of %d", position, *total)
+	}
+
+	switch update.Status {
+	case "PLANNING":
+		return planned
+	case "COMPLETED":
+		return "Completed"
+	case "DROPPED":
+		return "Dropped at " + position
+	case "PAUSED":
+		return "Paused at " + position
+	}
+
+	if progress == 0 {
+		return "Started"
+	}
+	if update.MediaType == anilistgo.MediaTypeManga && update.ProgressVol != nil && *update.ProgressVol > 0 {
+		return fmt.Sprintf("%s %s (volume %d)", verb, position, *update.ProgressVol)
+	}
+	return fmt.Sprintf("%s %s", verb, position)
+}
+
+// WriteRSS writes the updates as an RSS 2.0 document, newest first.
+//
+// Usage:
+//
+//	updates, err := anilistgo.GetUpdates("Ithilias", anilistgo.MediaTypeAnime, nil, nil)
+//	err = feed.WriteRSS(os.Stdout, feed.Options{Title: "Ithilias"}, updates)
+func WriteRSS(w io.Writer, options Options, updates []anilistgo.Update) error {
+	updates = sortedUpdates(updates)
+
+	channel := rssChannel{
+		Title:       options.Title,
+		Link:        options.Link,
+		Description: options.Description,
+	}
+	if len(updates) > 0 {
+		channel.LastBuildDate = updateTime(updates[0]).Format(time.RFC1123Z)
+	}
+
+	for _, update := range updates {
+		item := rssItem{
+			Title:       itemTitle(update),
+			Link:        update.URL,
+			Description: ProgressSummary(update),
+			GUID:        rssGUID{Value: itemID(update)},
+			PubDate:     updateTime(update).Format(time.RFC1123Z),
+		}
+		if update.CoverURL != "" {
+			item.Enclosure = &rssEnclosure{URL: update.CoverURL, Type: imageType(update.CoverURL)}
+		}
+		channel.Items = append(channel.Items, item)
+	}
+
+	return writeXML(w, rss{Version: "2.0", Channel: channel})
+}
+
+// WriteAtom writes the updates as an Atom 1.0 document, newest first. The
+// feed is authored by the user of the updates, or by AniList if they belong
+// to several users.
+func WriteAtom(w io.Writer, options Options, updates []anilistgo.Update) error {
+	updates = sortedUpdates(updates)
+
+	feed := atomFeed{
+		Title:    options.Title,
+		ID:       options.Link,
+		Subtitle: options.Description,
+		Updated:  time.Unix(0, 0).UTC().Format(time.RFC3339),
+		Author:   atomAuthor{Name: feedAuthor(updates)},
+	}
+	if feed.ID == "" {
+		feed.ID = "urn:anilistgo:feed:" + strings.ReplaceAll(options.Title, " ", "-")
+	}
+	if options.Link != "" {
+		feed.Links = append(feed.Links, atomLink{Href: options.Link, Rel: "alternate"})
+	}
+	if len(updates) > 0 {
+		feed.Updated = updateTime(updates[0]).Format(time.RFC3339)
+	}
+
+	for _, update := range updates {
+		entry := atomEntry{
+			Title:   itemTitle(update),
+			ID:      itemID(update),
+			Updated: updateTime(update).Format(time.RFC3339),
+			Summary: ProgressSummary(update),
+		}
+		if update.UserName != "" {
+			entry.Author = &atomAuthor{Name: update.UserName}
+		}
+		if update.URL != "" {
+			entry.Links = append(entry.Links, atomLink{Href: update.URL, Rel: "alternate"})
+		}
+		if update.CoverURL != "" {
+			entry.Links = append(entry.Links, atomLink{Href: update.CoverURL, Rel: "enclosure", Type: imageType(update.CoverURL)})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return writeXML(w, feed)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.Source == nil {
+		http.Error(w, "feed has no source", http.StatusInternalServerError)
+		return
+	}
+	updates, err := h.Source()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	var buf bytes.Buffer
+	contentType := RSSContentType
+	if h.Format == FormatAtom {
+		contentType = AtomContentType
+		err = WriteAtom(&buf, h.Options, updates)
+	} else {
+		err = WriteRSS(&buf, h.Options, updates)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Content-Type", contentType)
+
+	// http.ServeContent handles If-None-Match and If-Modified-Since, and falls
+	// back to the ETag when no update has a timestamp.
+	var modified time.Time
+	for _, update := range updates {
+		if t := updateTime(update); t.After(modified) {
+			modified = t
+		}
+	}
+	http.ServeContent(w, r, "", modified, bytes.NewReader(buf.Bytes()))
+}
+
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func sortedUpdates(updates []anilistgo.Update) []anilistgo.Update {
+	sorted := make([]anilistgo.Update, len(updates))
+	copy(sorted, updates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].UpdatedTime > sorted[j].UpdatedTime
+	})
+	return sorted
+}
+
+func updateTime(update anilistgo.Update) time.Time {
+	return time.Unix(update.UpdatedTime, 0).UTC()
+}
+
+// feedAuthor returns the user all updates belong to, or AniList for updates
+// of several or unnamed users, as Atom requires an author for every entry.
+func feedAuthor(updates []anilistgo.Update) string {
+	name := ""
+	for i, update := range updates {
+		if i > 0 && update.UserName != name {
+			return "AniList"
+		}
+		name = update.UserName
+	}
+	if name == "" {
+		return "AniList"
+	}
+	return name
+}
+
+func itemTitle(update anilistgo.Update) string {
+	if update.UserName == "" {
+		return update.Title
+	}
+	return update.UserName + ": " + update.Title
+}
+
+func itemID(update anilistgo.Update) string {
+	return fmt.Sprintf("urn:anilistgo:update:%s:%d:%d", update.UserName, update.MediaID, update.UpdatedTime)
+}
+
+func imageType(url string) string {
+	switch strings.ToLower(path.Ext(url)) {
+	case ".png":
+		return "image/png"
+	case ".gif":
+		return "image/gif"
+	case ".webp":
+		return "image/webp"
+	default:
+		return "image/jpeg"
+	}
+}
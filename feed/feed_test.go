@@ -0,0 +1,143 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Ithilias/anilistgo"
+)
+
+func testUpdates() []anilistgo.Update {
+	five, twelve := 5, 12
+	return []anilistgo.Update{
+		{UserName: "Ithilias", MediaID: 21, Title: "One Piece", URL: "https://anilist.co/anime/21", Status: "CURRENT", UpdatedTime: 100, Progress: &five, MediaType: anilistgo.MediaTypeAnime},
+		{UserName: "Ithilias", MediaID: 30, Title: "Berserk", URL: "https://anilist.co/manga/30", CoverURL: "https://example.com/cover.png", Status: "CURRENT", UpdatedTime: 200, Progress: &five, TotalChapters: &twelve, MediaType: anilistgo.MediaTypeManga},
+	}
+}
+
+func TestProgressSummary(t *testing.T) {
+	five, twelve := 5, 12
+	tests := []struct {
+		update   anilistgo.Update
+		expected string
+	}{
+		{anilistgo.Update{MediaType: anilistgo.MediaTypeAnime, Status: "CURRENT", Progress: &five, TotalEpisodes: &twelve}, "Watched episode 5 of 12"},
+		{anilistgo.Update{MediaType: anilistgo.MediaTypeAnime, Status: "CURRENT", Progress: &five}, "Watched episode 5"},
+		{anilistgo.Update{MediaType: anilistgo.MediaTypeManga, Status: "CURRENT", Progress: &five, ProgressVol: &five}, "Read chapter 5 (volume 5)"},
+		{anilistgo.Update{MediaType: anilistgo.MediaTypeAnime, Status: "DROPPED", Progress: &five, TotalEpisodes: &twelve}, "Dropped at episode 5 of 12"},
+		{anilistgo.Update{MediaType: anilistgo.MediaTypeManga, Status: "PLANNING"}, "Plans to read"},
+		{anilistgo.Update{MediaType: anilistgo.MediaTypeAnime, Status: "COMPLETED", Progress: &twelve}, "Completed"},
+	}
+
+	for _, tt := range tests {
+		if result := ProgressSummary(tt.update); result != tt.expected {
+			t.Errorf("expected summary %q but got %q", tt.expected, result)
+		}
+	}
+}
+
+func TestWriteRSS(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteRSS(&buf, Options{Title: "Ithilias", Link: "https://anilist.co/user/Ithilias"}, testUpdates()); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	var document rss
+	if err := xml.Unmarshal(buf.Bytes(), &document); err != nil {
+		t.Fatalf("expected valid XML but got: %v", err)
+	}
+	if len(document.Channel.Items) != 2 || document.Channel.Items[0].Title != "Ithilias: Berserk" {
+		t.Fatalf("expected newest item first but got %v", document.Channel.Items)
+	}
+	if enclosure := document.Channel.Items[0].Enclosure; enclosure == nil || enclosure.Type != "image/png" {
+		t.Errorf("expected png enclosure but got %v", enclosure)
+	}
+	if document.Channel.Items[1].Enclosure != nil {
+		t.Errorf("expected no enclosure without a cover but got %v", document.Channel.Items[1].Enclosure)
+	}
+}
+
+func TestWriteAtom(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteAtom(&buf, Options{Title: "Ithilias"}, testUpdates()); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	var document atomFeed
+	if err := xml.Unmarshal(buf.Bytes(), &document); err != nil {
+		t.Fatalf("expected valid XML but got: %v", err)
+	}
+	if len(document.Entries) != 2 || document.Updated != "1970-01-01T00:03:20Z" {
+		t.Errorf("expected 2 entries updated at the newest update but got %v", document)
+	}
+	if document.Author.Name != "Ithilias" {
+		t.Errorf("expected the user as the author of the feed but got %q", document.Author.Name)
+	}
+}
+
+func TestWriteAtomDefaultsAuthor(t *testing.T) {
+	updates := testUpdates()
+	for i := range updates {
+		updates[i].UserName = ""
+	}
+	mixed := testUpdates()
+	mixed[1].UserName = "Someone"
+
+	for _, updates := range [][]anilistgo.Update{nil, updates, mixed} {
+		var buf bytes.Buffer
+		if err := WriteAtom(&buf, Options{Title: "Updates"}, updates); err != nil {
+			t.Fatalf("expected no error but got: %v", err)
+		}
+		var document atomFeed
+		if err := xml.Unmarshal(buf.Bytes(), &document); err != nil {
+			t.Fatalf("expected valid XML but got: %v", err)
+		}
+		if document.Author.Name != "AniList" {
+			t.Errorf("expected AniList as the author of the feed but got %q", document.Author.Name)
+		}
+	}
+}
+
+func TestHandlerWithoutSource(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	(&Handler{}).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/feed", nil))
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500 without a source but got %d", recorder.Code)
+	}
+}
+
+func TestHandlerConditionalGet(t *testing.T) {
+	handler := &Handler{Format: FormatAtom, Source: func() ([]anilistgo.Update, error) { return testUpdates(), nil }}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/feed", nil))
+	if recorder.Code != http.StatusOK || !strings.HasPrefix(recorder.Header().Get("Content-Type"), "application/atom+xml") {
+		t.Fatalf("expected atom feed but got %d %s", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+
+	etag := recorder.Header().Get("ETag")
+	lastModified := recorder.Header().Get("Last-Modified")
+	if etag == "" || lastModified == "" {
+		t.Fatalf("expected ETag and Last-Modified headers but got %q and %q", etag, lastModified)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/feed", nil)
+	request.Header.Set("If-None-Match", etag)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusNotModified {
+		t.Errorf("expected status 304 for matching ETag but got %d", recorder.Code)
+	}
+
+	request = httptest.NewRequest(http.MethodGet, "/feed", nil)
+	request.Header.Set("If-Modified-Since", lastModified)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusNotModified {
+		t.Errorf("expected status 304 for If-Modified-Since but got %d", recorder.Code)
+	}
+}
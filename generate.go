@@ -0,0 +1,8 @@
+package anilistgo
+
+// The types of generated.go are generated from schema/anilist.json, a subset
+// of the result of the AniList introspection query. To generate another
+// type, add it to the schema file and to the -types list below. The
+// operations of the graphql directory get typed wrappers.
+//
+//go:generate go run ./cmd/anilistgen -schema schema/anilist.json -types MediaListStatus,ScoreFormat,MediaListCollection,MediaListGroup,FuzzyDateInput -map MediaList=MediaListEntry,Media=Media,User=User -operations graphql -o generated.go
@@ -0,0 +1,132 @@
+// Code generated by anilistgen. DO NOT EDIT.
+
+package anilistgo
+
+import (
+	"context"
+)
+
+// MediaListStatus: Media list watching/reading status enum.
+type MediaListStatus string
+
+const (
+	// Currently watching/reading
+	MediaListStatusCurrent MediaListStatus = "CURRENT"
+	// Planning to watch/read
+	MediaListStatusPlanning MediaListStatus = "PLANNING"
+	// Finished watching/reading
+	MediaListStatusCompleted MediaListStatus = "COMPLETED"
+	// Stopped watching/reading before completing
+	MediaListStatusDropped MediaListStatus = "DROPPED"
+	// Paused watching/reading
+	MediaListStatusPaused MediaListStatus = "PAUSED"
+	// Re-watching/reading
+	MediaListStatusRepeating MediaListStatus = "REPEATING"
+)
+
+// ScoreFormat: Media list scoring type
+type ScoreFormat string
+
+const (
+	// An integer from 0-100
+	ScoreFormatPoint100 ScoreFormat = "POINT_100"
+	// A float from 0-10 with 1 decimal place
+	ScoreFormatPoint10Decimal ScoreFormat = "POINT_10_DECIMAL"
+	// An integer from 0-10
+	ScoreFormatPoint10 ScoreFormat = "POINT_10"
+	// An integer from 0-5. Should be represented in Stars
+	ScoreFormatPoint5 ScoreFormat = "POINT_5"
+	// An integer from 0-3. Should be represented in Smileys. 0 => No Score, 1 => :(, 2 => :|, 3 => :)
+	ScoreFormatPoint3 ScoreFormat = "POINT_3"
+)
+
+// MediaListCollection: List of anime or manga
+type MediaListCollection struct {
+	// Grouped media list entries
+	Lists []MediaListGroup `json:"lists"`
+	// The owner of the list
+	User *User `json:"user,omitempty"`
+	// If there is another chunk
+	HasNextChunk bool `json:"hasNextChunk"`
+}
+
+// MediaListGroup: List group of anime or manga entries
+type MediaListGroup struct {
+	// Media list entries
+	Entries              []MediaListEntry `json:"entries"`
+	Name                 string           `json:"name"`
+	IsCustomList         bool             `json:"isCustomList"`
+	IsSplitCompletedList bool             `json:"isSplitCompletedList"`
+	Status               MediaListStatus  `json:"status"`
+}
+
+// FuzzyDateInput: Date object that allows for incomplete date values (fuzzy)
+type FuzzyDateInput struct {
+	// Numeric Year (2017)
+	Year *int `json:"year,omitempty"`
+	// Numeric Month (3)
+	Month *int `json:"month,omitempty"`
+	// Numeric Day (24)
+	Day *int `json:"day,omitempty"`
+}
+
+// MediaListChunkDocument is the query of media_list_chunk.graphql.
+const MediaListChunkDocument = `# Loads one chunk of a user's list, most recently updated first. Keep
+# requesting the next chunk while hasNextChunk is true.
+query MediaListChunk($userName: String, $type: MediaType, $status: MediaListStatus, $chunk: Int, $perChunk: Int) {
+  MediaListCollection(userName: $userName, type: $type, status: $status, chunk: $chunk, perChunk: $perChunk, sort: UPDATED_TIME_DESC) {
+    hasNextChunk
+    lists {
+      name
+      isCustomList
+      isSplitCompletedList
+      status
+      entries {
+        id
+        mediaId
+        status
+        score(format: POINT_100)
+        progress
+        progressVolumes
+        repeat
+        notes
+        updatedAt
+        createdAt
+        media {
+          id
+          idMal
+          type
+          title {
+            romaji
+            english
+            native
+          }
+          episodes
+          chapters
+          volumes
+        }
+      }
+    }
+  }
+}`
+
+// MediaListChunkVariables are the variables of MediaListChunkDocument. Zero values are not sent.
+type MediaListChunkVariables struct {
+	UserName string          `json:"userName,omitempty"`
+	Type     string          `json:"type,omitempty"`
+	Status   MediaListStatus `json:"status,omitempty"`
+	Chunk    int             `json:"chunk,omitempty"`
+	PerChunk int             `json:"perChunk,omitempty"`
+}
+
+// MediaListChunkResult is the data returned by MediaListChunkDocument.
+type MediaListChunkResult struct {
+	MediaListCollection *MediaListCollection `json:"MediaListCollection"`
+}
+
+// MediaListChunk sends the MediaListChunk query to AniList.
+func MediaListChunk(ctx context.Context, vars MediaListChunkVariables) (MediaListChunkResult, error) {
+	var result MediaListChunkResult
+	err := Query(ctx, MediaListChunkDocument, vars, &result)
+	return result, err
+}
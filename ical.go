@@ -0,0 +1,180 @@
+package anilistgo
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	CalendarContentType    = "text/calendar; charset=utf-8"
+	CalendarLookBehind     = 7 * 24 * time.Hour
+	DefaultEpisodeDuration = 24
+
+	calendarTimeFormat = "20060102T150405Z"
+	calendarLineLength = 75
+)
+
+// GetAiringCalendar retrieves the airing episodes of every show on a user's
+// CURRENT and PLANNING anime lists, from CalendarLookBehind ago onwards. The
+// result can be written as an iCalendar file with WriteCalendar.
+//
+// Parameters:
+//   - username: The username of the user whose lists are used.
+//
+// Returns:
+//   - A slice of UpcomingEpisode structs, sorted by airing time.
+//   - An error if there's any issue fetching the lists or the schedules.
+func GetAiringCalendar(username string) ([]UpcomingEpisode, error) {
+	updates, err := GetUpdates(username, MediaTypeAnime, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := AiringScheduleFilter{
+		AiringAfter: time.Now().Add(-CalendarLookBehind),
+	}
+	return fetchUpcomingEpisodes(updatesByMediaID(updates, "CURRENT", "PLANNING"), filter)
+}
+
+// WriteCalendar writes the given episodes as an RFC 5545 iCalendar document.
+// Every episode becomes an event with a UID that is stable across calls, so
+// calendar apps update existing events instead of duplicating them.
+//
+// Parameters:
+//   - w: The writer to write the calendar to.
+//   - name: The display name of the calendar.
+//   - episodes: The episodes to include, as returned by GetAiringCalendar.
+//   - now: The time used for the DTSTAMP of every event.
+//
+// Usage:
+//
+//	episodes, err := GetAiringCalendar("Ithilias")
+//	err = WriteCalendar(file, "Ithilias' airing schedule", episodes, time.Now())
+func WriteCalendar(w io.Writer, name string, episodes []UpcomingEpisode, now time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(content string) {
+		writeCalendarLine(bw, content)
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//anilistgo//Airing Schedule//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:" + escapeCalendarText(name))
+
+	stamp := now.UTC().Format(calendarTimeFormat)
+	for _, episode := range episodes {
+		schedule := episode.Schedule
+		url := fmt.Sprintf(AnilistURLFormat, strings.ToLower(MediaTypeAnime), schedule.MediaID)
+
+		title := episode.Update.Title
+		duration := DefaultEpisodeDuration
+		if schedule.Media != nil {
+			if title == "" {
+				title = schedule.Media.Title.English
+			}
+			if title == "" {
+				title = schedule.Media.Title.Romaji
+			}
+			if schedule.Media.Duration != nil && *schedule.Media.Duration > 0 {
+				duration = *schedule.Media.Duration
+			}
+		}
+
+		summary := fmt.Sprintf("%s - Episode %d", title, schedule.Episode)
+		description := fmt.Sprintf("Episode %d of %s\n%s", schedule.Episode, title, url)
+
+		line("BEGIN:VEVENT")
+		line(fmt.Sprintf("UID:anilist-%d-%d@anilist.co", schedule.MediaID, schedule.Episode))
+		line("DTSTAMP:" + stamp)
+		line("DTSTART:" + schedule.AiringTime().UTC().Format(calendarTimeFormat))
+		line(fmt.Sprintf("DURATION:PT%dM", duration))
+		line("SUMMARY:" + escapeCalendarText(summary))
+		line("DESCRIPTION:" + escapeCalendarText(description))
+		line("URL:" + url)
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+	return bw.Flush()
+}
+
+// NewCalendarHandler returns an http.Handler that serves the airing calendar
+// of the given user, so it can be subscribed to from calendar apps. The user
+// can be overridden with the "user" query parameter, which allows a single
+// handler to serve the calendars of a whole team.
+//
+// Usage:
+//
+//	http.Handle("/calendar.ics", NewCalendarHandler("Ithilias"))
+//	log.Fatal(http.ListenAndServe(":8080", nil))
+func NewCalendarHandler(username string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		user := username
+		if queryUser := r.URL.Query().Get("user"); queryUser != "" {
+			user = queryUser
+		}
+		if user == "" {
+			http.Error(w, "missing user", http.StatusBadRequest)
+			return
+		}
+
+		episodes, err := GetAiringCalendar(user)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := WriteCalendar(&buf, user+"'s airing schedule", episodes, time.Now()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", CalendarContentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", user+".ics"))
+		_, _ = w.Write(buf.Bytes())
+	})
+}
+
+// writeCalendarLine writes a content line terminated by CRLF, folding it into
+// continuation lines of at most 75 octets without splitting UTF-8 sequences.
+func writeCalendarLine(w *bufio.Writer, content string) {
+	limit := calendarLineLength
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		_, _ = w.WriteString(content[:cut])
+		_, _ = w.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space, which counts towards the limit.
+		limit = calendarLineLength - 1
+	}
+	_, _ = w.WriteString(content)
+	_, _ = w.WriteString("\r\n")
+}
+
+func escapeCalendarText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
@@ -0,0 +1,54 @@
+package anilistgo
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteCalendar(t *testing.T) {
+	duration := 23
+	episodes := []UpcomingEpisode{
+		{
+			Update: Update{MediaID: 21, Title: "One Piece, the very long title of a show that needs folding"},
+			Schedule: AiringSchedule{
+				MediaID:  21,
+				Episode:  1100,
+				AiringAt: time.Date(2024, 3, 10, 1, 30, 0, 0, time.UTC).Unix(),
+				Media:    &Media{ID: 21, Duration: &duration},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCalendar(&buf, "Test", episodes, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	calendar := buf.String()
+
+	for _, expected := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:anilist-21-1100@anilist.co\r\n",
+		"DTSTAMP:20240301T000000Z\r\n",
+		"DTSTART:20240310T013000Z\r\n",
+		"DURATION:PT23M\r\n",
+		"URL:https://anilist.co/anime/21\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(calendar, expected) {
+			t.Errorf("expected calendar to contain %q but got:\n%s", expected, calendar)
+		}
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(calendar, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("expected lines of at most 75 octets but got %d: %q", len(line), line)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(calendar, "\r\n ", "")
+	if !strings.Contains(unfolded, `SUMMARY:One Piece\, the very long title of a show that needs folding - Episode 1100`) {
+		t.Errorf("expected escaped summary but got:\n%s", unfolded)
+	}
+}
@@ -0,0 +1,459 @@
+package anilistgo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMutationInterval keeps bulk mutations below AniList's rate limit
+	// of 90 requests per minute.
+	DefaultMutationInterval = 700 * time.Millisecond
+
+	MediaListCollectionQuery = `
+    query ($userName: String, $type: MediaType) {
+        MediaListCollection(userName: $userName, type: $type) {
+            lists {
+                name
+                isCustomList
+                status
+                entries {
+                    id
+                    mediaId
+                    score (format: POINT_100)
+                    progress
+                    progressVolumes
+                    status
+                    repeat
+                    priority
+                    private
+                    notes
+                    startedAt {
+                        year
+                        month
+                        day
+                    }
+                    completedAt {
+                        year
+                        month
+                        day
+                    }
+                    updatedAt
+                    createdAt
+                    media {
+                        id
+                        idMal
+                        type
+                        format
+                        title {
+                            romaji
+                            english
+                            native
+                        }
+                        coverImage {
+                            extraLarge
+                        }
+                        episodes
+                        chapters
+                        volumes
+                        duration
+                    }
+                }
+            }
+        }
+    }
+    `
+
+	SaveMediaListEntryQuery = `
+    mutation ($mediaId: Int, $status: MediaListStatus, $scoreRaw: Int, $progress: Int, $progressVolumes: Int, $repeat: Int, $notes: String, $startedAt: FuzzyDateInput, $completedAt: FuzzyDateInput) {
+      SaveMediaListEntry (mediaId: $mediaId, status: $status, scoreRaw: $scoreRaw, progress: $progress, progressVolumes: $progressVolumes, repeat: $repeat, notes: $notes, startedAt: $startedAt, completedAt: $completedAt) {
+        id
+        mediaId
+        status
+        score (format: POINT_100)
+        progress
+        progressVolumes
+        repeat
+        notes
+        updatedAt
+      }
+    }
+    `
+)
+
+// SaveMediaListEntryOp describes a change to a single list entry. Only the
+// fields that are set are sent, so unset fields keep their current value on
+// AniList. Score uses the POINT_100 format.
+type SaveMediaListEntryOp struct {
+	MediaID         int        `json:"mediaId"`
+	Status          string     `json:"status,omitempty"`
+	Score           *int       `json:"score,omitempty"`
+	Progress        *int       `json:"progress,omitempty"`
+	ProgressVolumes *int       `json:"progressVolumes,omitempty"`
+	Repeat          *int       `json:"repeat,omitempty"`
+	Notes           *string    `json:"notes,omitempty"`
+	StartedAt       *FuzzyDate `json:"startedAt,omitempty"`
+	CompletedAt     *FuzzyDate `json:"completedAt,omitempty"`
+}
+
+// ApplyOptions controls how ApplyOperations sends a batch of operations.
+// Interval is an additional minimum time between two requests, on top of the
+// rate limit shared by all calls of the package. If CheckpointPath is set,
+// the operations are stored in that file along with the media IDs of saved
+// entries and the IDs of deleted entries, so an interrupted run can be
+// resumed. A run resumes the operations of an existing checkpoint rather than
+// the given ones, since a plan built again after a partial run, such as by
+// PlanMALImport, leaves out the entries that were already saved. The given
+// operations must be part of the stored ones, and the checkpoint is removed
+// once every operation is applied.
+type ApplyOptions struct {
+	Interval       time.Duration
+	CheckpointPath string
+}
+
+type applyCheckpoint struct {
+	Plan    SyncPlan `json:"plan"`
+	Applied []int    `json:"applied"`
+	Deleted []int    `json:"deleted,omitempty"`
+}
+
+// FuzzyDate is a date where any part may be unknown, as used by AniList for
+// start and completion dates.
+type FuzzyDate struct {
+	Year  *int `json:"year"`
+	Month *int `json:"month"`
+	Day   *int `json:"day"`
+}
+
+// GetMediaListCollection retrieves every entry of a user's anime or manga
+// list, including the details that GetUpdates leaves out, such as dates,
+// repeat counts, notes and the MyAnimeList ID of the media. Entries that are
+// part of custom lists are only returned once.
+//
+// Parameters:
+//   - username: The username of the user whose list is fetched.
+//   - mediaType: Either MediaTypeAnime or MediaTypeManga.
+//
+// Returns:
+//   - A slice of MediaListEntry structs, one per media on the list.
+//   - An error if there's any issue fetching the data or if the provided
+//     mediaType is invalid.
+func GetMediaListCollection(username string, mediaType string) ([]MediaListEntry, error) {
+	if mediaType != MediaTypeAnime && mediaType != MediaTypeManga {
+		return nil, fmt.Errorf("invalid mediaType provided: %s. Accepts only %s or %s", mediaType, MediaTypeAnime, MediaTypeManga)
+	}
+
+	variables := map[string]interface{}{
+		"userName": username,
+		"type":     mediaType,
+	}
+
+	mediaListCollection, err := fetchUpdatesData(MediaListCollectionQuery, variables)
+	if err != nil {
+		return nil, err
+	}
+	if mediaListCollection == nil {
+		return nil, nil
+	}
+
+	var entries []MediaListEntry
+	seen := make(map[int]bool)
+	for _, list := range mediaListCollection.Lists {
+		for _, entry := range list.Entries {
+			if seen[entry.ID] {
+				continue
+			}
+			seen[entry.ID] = true
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// ToUpdate converts the entry to an Update, the flat representation returned by
+// GetUpdates, for the given user and media type.
+func (e MediaListEntry) ToUpdate(userName string, mediaType string) Update {
+	update := Update{
+		UserName:    userName,
+		MediaID:     e.MediaID,
+		Title:       e.Media.Title.English,
+		URL:         fmt.Sprintf(AnilistURLFormat, strings.ToLower(mediaType), e.MediaID),
+		CoverURL:    e.Media.CoverImage.ExtraLarge,
+		Status:      e.Status,
+		UpdatedTime: e.UpdatedAt,
+		Score:       e.Score,
+		MediaType:   mediaType,
+	}
+
+	if update.Title == "" {
+		update.Title = e.Media.Title.Romaji
+	}
+
+	if mediaType == MediaTypeAnime {
+		update.Progress = e.Progress
+		update.TotalEpisodes = e.Media.Episodes
+		update.Duration = e.Media.Duration
+	} else if mediaType == MediaTypeManga {
+		update.Progress = e.Progress
+		update.ProgressVol = e.ProgressVolumes
+		update.TotalVolumes = e.Media.Volumes
+		update.TotalChapters = e.Media.Chapters
+	}
+
+	return update
+}
+
+// SaveMediaListEntry creates or updates the authenticated user's list entry
+// for the media of the given operation, and returns the saved entry. Cached
+// responses holding the media and cached lists are removed from the cache,
+// see SetCache.
+//
+// Usage:
+//
+//	progress := 7
+//	entry, err := api.SaveMediaListEntry(SaveMediaListEntryOp{MediaID: 21, Status: "CURRENT", Progress: &progress})
+func (api *AuthenticatedAPI) SaveMediaListEntry(op SaveMediaListEntryOp) (MediaListEntry, error) {
+	data, err := sendRequest(BaseAPIURL, SaveMediaListEntryQuery, op.variables(), api.AccessToken)
+	if err != nil {
+		return MediaListEntry{}, err
+	}
+	if data.Data.SaveMediaListEntry == nil {
+		return MediaListEntry{}, fmt.Errorf("list entry for media %d was not saved", op.MediaID)
+	}
+	invalidateListEntry(op.MediaID)
+	return *data.Data.SaveMediaListEntry, nil
+}
+
+// ApplyOperations saves every operation with SaveMediaListEntry, in order,
+// until the context is cancelled. Requests are rate limited like every call
+// of the package, see SetRateLimit, and spaced by at least Interval if it is
+// set.
+//
+// Returns:
+//   - The number of operations applied by this call, not counting the ones
+//     skipped because of the checkpoint.
+//   - An error if an operation fails, the checkpoint cannot be written or the
+//     context is cancelled. Operations before the failing one stay applied.
+//
+// Usage:
+//
+//	applied, err := api.ApplyOperations(ctx, plan.Operations, ApplyOptions{CheckpointPath: "import.checkpoint"})
+func (api *AuthenticatedAPI) ApplyOperations(ctx context.Context, ops []SaveMediaListEntryOp, options ApplyOptions) (int, error) {
+	return api.applyPlan(ctx, SyncPlan{Save: ops}, options)
+}
+
+func (api *AuthenticatedAPI) applyPlan(ctx context.Context, plan SyncPlan, options ApplyOptions) (int, error) {
+	save := func(op SaveMediaListEntryOp) error {
+		_, err := api.SaveMediaListEntry(op)
+		return err
+	}
+	remove := func(entry MediaListEntry) error {
+		return api.DeleteMediaListEntry(entry.ID)
+	}
+	return applyPlan(ctx, plan, options, save, remove)
+}
+
+// applyPlan saves and then deletes the entries of a plan, or of the plan of
+// the checkpoint if there is one, recording the media IDs of saved entries
+// and the IDs of deleted entries in the checkpoint.
+func applyPlan(ctx context.Context, plan SyncPlan, options ApplyOptions, save func(op SaveMediaListEntryOp) error, remove func(entry MediaListEntry) error) (int, error) {
+	checkpoint, err := readApplyCheckpoint(options.CheckpointPath, plan)
+	if err != nil {
+		return 0, err
+	}
+	plan = checkpoint.Plan
+	saved := make(map[int]bool, len(checkpoint.Applied))
+	for _, mediaID := range checkpoint.Applied {
+		saved[mediaID] = true
+	}
+	deleted := make(map[int]bool, len(checkpoint.Deleted))
+	for _, id := range checkpoint.Deleted {
+		deleted[id] = true
+	}
+
+	applied := 0
+	wait := func() error {
+		if applied > 0 && options.Interval > 0 {
+			select {
+			case <-time.After(options.Interval):
+			case <-ctx.Done():
+			}
+		}
+		return ctx.Err()
+	}
+	record := func() error {
+		if options.CheckpointPath == "" {
+			return nil
+		}
+		return writeApplyCheckpoint(options.CheckpointPath, checkpoint)
+	}
+
+	for _, op := range plan.Save {
+		if saved[op.MediaID] {
+			continue
+		}
+		if err := wait(); err != nil {
+			return applied, err
+		}
+
+		if err := save(op); err != nil {
+			return applied, fmt.Errorf("saving media %d: %w", op.MediaID, err)
+		}
+		applied++
+
+		checkpoint.Applied = append(checkpoint.Applied, op.MediaID)
+		if err := record(); err != nil {
+			return applied, err
+		}
+	}
+
+	for _, entry := range plan.Delete {
+		if deleted[entry.ID] {
+			continue
+		}
+		if err := wait(); err != nil {
+			return applied, err
+		}
+
+		if err := remove(entry); err != nil {
+			return applied, fmt.Errorf("deleting media %d: %w", entry.MediaID, err)
+		}
+		applied++
+
+		checkpoint.Deleted = append(checkpoint.Deleted, entry.ID)
+		if err := record(); err != nil {
+			return applied, err
+		}
+	}
+
+	if options.CheckpointPath != "" {
+		if err := os.Remove(options.CheckpointPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return applied, err
+		}
+	}
+	return applied, nil
+}
+
+// readApplyCheckpoint reads the checkpoint at path, or returns an empty one
+// for plan if there is none yet. It returns an error if plan has operations
+// that the plan of the checkpoint does not.
+func readApplyCheckpoint(path string, plan SyncPlan) (applyCheckpoint, error) {
+	checkpoint := applyCheckpoint{Plan: plan}
+	if path == "" {
+		return checkpoint, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return checkpoint, err
+	}
+
+	var stored applyCheckpoint
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return checkpoint, err
+	}
+	if !containsPlan(stored.Plan, plan) {
+		return checkpoint, fmt.Errorf("checkpoint %s was written for another plan", path)
+	}
+	return stored, nil
+}
+
+// containsPlan reports whether every save of part, by media ID, and every
+// deletion, by entry ID, is in plan.
+func containsPlan(plan SyncPlan, part SyncPlan) bool {
+	saves := make(map[int]bool, len(plan.Save))
+	for _, op := range plan.Save {
+		saves[op.MediaID] = true
+	}
+	deletions := make(map[int]bool, len(plan.Delete))
+	for _, entry := range plan.Delete {
+		deletions[entry.ID] = true
+	}
+
+	for _, op := range part.Save {
+		if !saves[op.MediaID] {
+			return false
+		}
+	}
+	for _, entry := range part.Delete {
+		if !deletions[entry.ID] {
+			return false
+		}
+	}
+	return true
+}
+
+func writeApplyCheckpoint(path string, checkpoint applyCheckpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (op SaveMediaListEntryOp) variables() map[string]interface{} {
+	variables := map[string]interface{}{
+		"mediaId": op.MediaID,
+	}
+	if op.Status != "" {
+		variables["status"] = op.Status
+	}
+	if op.Score != nil {
+		variables["scoreRaw"] = *op.Score
+	}
+	if op.Progress != nil {
+		variables["progress"] = *op.Progress
+	}
+	if op.ProgressVolumes != nil {
+		variables["progressVolumes"] = *op.ProgressVolumes
+	}
+	if op.Repeat != nil {
+		variables["repeat"] = *op.Repeat
+	}
+	if op.Notes != nil {
+		variables["notes"] = *op.Notes
+	}
+	if op.StartedAt != nil {
+		variables["startedAt"] = *op.StartedAt
+	}
+	if op.CompletedAt != nil {
+		variables["completedAt"] = *op.CompletedAt
+	}
+	return variables
+}
+
+// NewFuzzyDate returns a FuzzyDate with all parts of the given time set.
+func NewFuzzyDate(t time.Time) FuzzyDate {
+	year, month, day := t.Year(), int(t.Month()), t.Day()
+	return FuzzyDate{Year: &year, Month: &month, Day: &day}
+}
+
+// IsZero reports whether no part of the date is known.
+func (d FuzzyDate) IsZero() bool {
+	return d.Year == nil && d.Month == nil && d.Day == nil
+}
+
+// String formats the date as YYYY-MM-DD, using zeros for unknown parts.
+func (d FuzzyDate) String() string {
+	part := func(value *int) int {
+		if value == nil {
+			return 0
+		}
+		return *value
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", part(d.Year), part(d.Month), part(d.Day))
+}
@@ -0,0 +1,70 @@
+package anilistgo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApplyPlanResumesStoredPlan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "import.checkpoint")
+	options := ApplyOptions{CheckpointPath: path}
+	plan := SyncPlan{Save: []SaveMediaListEntryOp{{MediaID: 1}, {MediaID: 2}, {MediaID: 3}}}
+
+	var saved []int
+	failOn := 2
+	save := func(op SaveMediaListEntryOp) error {
+		if op.MediaID == failOn {
+			return errors.New("server error")
+		}
+		saved = append(saved, op.MediaID)
+		return nil
+	}
+	remove := func(entry MediaListEntry) error { return nil }
+
+	applied, err := applyPlan(context.Background(), plan, options, save, remove)
+	if err == nil || applied != 1 {
+		t.Fatalf("expected the second operation to fail after one was applied but got %d, %v", applied, err)
+	}
+
+	// Planning again leaves out the entry that was saved, which must not
+	// make the checkpoint unusable.
+	failOn = 0
+	replanned := SyncPlan{Save: plan.Save[1:]}
+	applied, err = applyPlan(context.Background(), replanned, options, save, remove)
+	if err != nil || applied != 2 {
+		t.Fatalf("expected the remaining two operations to be applied but got %d, %v", applied, err)
+	}
+	if len(saved) != 3 || saved[0] != 1 || saved[1] != 2 || saved[2] != 3 {
+		t.Errorf("expected every operation to be saved once but got %v", saved)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the checkpoint to be removed once the plan is applied but got %v", err)
+	}
+}
+
+func TestApplyCheckpointBelongsToPlan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "import.checkpoint")
+	plan := SyncPlan{Save: []SaveMediaListEntryOp{{MediaID: 21}, {MediaID: 30}}}
+
+	checkpoint, err := readApplyCheckpoint(path, plan)
+	if err != nil || len(checkpoint.Applied) != 0 {
+		t.Fatalf("expected an empty checkpoint but got %+v, %v", checkpoint, err)
+	}
+
+	checkpoint.Applied = append(checkpoint.Applied, 21)
+	if err := writeApplyCheckpoint(path, checkpoint); err != nil {
+		t.Fatal(err)
+	}
+	checkpoint, err = readApplyCheckpoint(path, SyncPlan{Save: plan.Save[1:]})
+	if err != nil || len(checkpoint.Applied) != 1 || len(checkpoint.Plan.Save) != 2 {
+		t.Errorf("expected the stored plan and its applied media but got %+v, %v", checkpoint, err)
+	}
+
+	otherPlan := SyncPlan{Save: []SaveMediaListEntryOp{{MediaID: 5114}}}
+	if _, err := readApplyCheckpoint(path, otherPlan); err == nil {
+		t.Error("expected an error for a checkpoint of another plan")
+	}
+}
@@ -0,0 +1,317 @@
+package anilistgo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+)
+
+const (
+	malExportTypeAnime = 1
+	malExportTypeManga = 2
+	malEmptyDate       = "0000-00-00"
+)
+
+// malStatuses maps AniList list statuses to the MyAnimeList status names used
+// for anime and manga respectively. REPEATING has no MyAnimeList equivalent
+// and is exported as watching/reading with the rewatching flag set.
+var malStatuses = map[string][2]string{
+	"CURRENT":   {"Watching", "Reading"},
+	"REPEATING": {"Watching", "Reading"},
+	"COMPLETED": {"Completed", "Completed"},
+	"PAUSED":    {"On-Hold", "On-Hold"},
+	"DROPPED":   {"Dropped", "Dropped"},
+	"PLANNING":  {"Plan to Watch", "Plan to Read"},
+}
+
+var malSeriesTypes = map[string]string{
+	"TV":       "TV",
+	"TV_SHORT": "TV",
+	"MOVIE":    "Movie",
+	"SPECIAL":  "Special",
+	"OVA":      "OVA",
+	"ONA":      "ONA",
+	"MUSIC":    "Music",
+}
+
+type malCDATA struct {
+	Value string `xml:",cdata"`
+}
+
+type malDocument struct {
+	XMLName xml.Name   `xml:"myanimelist"`
+	MyInfo  malMyInfo  `xml:"myinfo"`
+	Anime   []malAnime `xml:"anime"`
+	Manga   []malManga `xml:"manga"`
+}
+
+type malMyInfo struct {
+	UserID         int    `xml:"user_id"`
+	UserName       string `xml:"user_name"`
+	UserExportType int    `xml:"user_export_type"`
+	Total          int    `xml:"user_total_anime,omitempty"`
+	TotalManga     int    `xml:"user_total_manga,omitempty"`
+	Watching       int    `xml:"user_total_watching,omitempty"`
+	Reading        int    `xml:"user_total_reading,omitempty"`
+	Completed      int    `xml:"user_total_completed"`
+	OnHold         int    `xml:"user_total_onhold"`
+	Dropped        int    `xml:"user_total_dropped"`
+	PlanToWatch    int    `xml:"user_total_plantowatch,omitempty"`
+	PlanToRead     int    `xml:"user_total_plantoread,omitempty"`
+}
+
+type malAnime struct {
+	ID              int      `xml:"series_animedb_id"`
+	Title           malCDATA `xml:"series_title"`
+	Type            string   `xml:"series_type"`
+	Episodes        int      `xml:"series_episodes"`
+	MyID            int      `xml:"my_id"`
+	WatchedEpisodes int      `xml:"my_watched_episodes"`
+	StartDate       string   `xml:"my_start_date"`
+	FinishDate      string   `xml:"my_finish_date"`
+	Rated           string   `xml:"my_rated"`
+	Score           int      `xml:"my_score"`
+	Storage         string   `xml:"my_storage"`
+	StorageValue    string   `xml:"my_storage_value"`
+	Status          string   `xml:"my_status"`
+	Comments        malCDATA `xml:"my_comments"`
+	TimesWatched    int      `xml:"my_times_watched"`
+	RewatchValue    string   `xml:"my_rewatch_value"`
+	Priority        string   `xml:"my_priority"`
+	Tags            malCDATA `xml:"my_tags"`
+	Rewatching      int      `xml:"my_rewatching"`
+	RewatchingEp    int      `xml:"my_rewatching_ep"`
+	Discuss         int      `xml:"my_discuss"`
+	SNS             string   `xml:"my_sns"`
+	UpdateOnImport  int      `xml:"update_on_import"`
+}
+
+type malManga struct {
+	ID               int      `xml:"manga_mangadb_id"`
+	Title            malCDATA `xml:"manga_title"`
+	Volumes          int      `xml:"manga_volumes"`
+	Chapters         int      `xml:"manga_chapters"`
+	MyID             int      `xml:"my_id"`
+	ReadVolumes      int      `xml:"my_read_volumes"`
+	ReadChapters     int      `xml:"my_read_chapters"`
+	StartDate        string   `xml:"my_start_date"`
+	FinishDate       string   `xml:"my_finish_date"`
+	ScanalationGroup malCDATA `xml:"my_scanalation_group"`
+	Score            int      `xml:"my_score"`
+	Storage          string   `xml:"my_storage"`
+	RetailVolumes    int      `xml:"my_retail_volumes"`
+	Status           string   `xml:"my_status"`
+	Comments         malCDATA `xml:"my_comments"`
+	TimesRead        int      `xml:"my_times_read"`
+	Tags             malCDATA `xml:"my_tags"`
+	Priority         string   `xml:"my_priority"`
+	RereadValue      string   `xml:"my_reread_value"`
+	Rereading        int      `xml:"my_rereading"`
+	Discuss          int      `xml:"my_discuss"`
+	SNS              string   `xml:"my_sns"`
+	UpdateOnImport   int      `xml:"update_on_import"`
+}
+
+// ExportMAL writes the given list entries as a MyAnimeList import XML
+// document. Statuses, scores, progress, volumes, dates, rewatch counts and
+// notes are mapped to their MyAnimeList equivalents. MyAnimeList only imports
+// one media type per file, so anime and manga have to be exported separately.
+//
+// Parameters:
+//   - w: The writer to write the XML document to.
+//   - username: The name written to the myinfo section of the document.
+//   - mediaType: Either MediaTypeAnime or MediaTypeManga, matching the entries.
+//   - entries: The list entries to export, as returned by
+//     GetMediaListCollection.
+//
+// Returns:
+//   - The entries that were not exported because their media has no
+//     MyAnimeList ID.
+//   - An error if the mediaType is invalid or writing fails.
+//
+// Usage:
+//
+//	entries, err := GetMediaListCollection("Ithilias", MediaTypeAnime)
+//	missing, err := ExportMAL(file, "Ithilias", MediaTypeAnime, entries)
+//	for _, entry := range missing {
+//	    fmt.Printf("%s has no MyAnimeList entry\n", entry.Media.Title.Romaji)
+//	}
+func ExportMAL(w io.Writer, username string, mediaType string, entries []MediaListEntry) ([]MediaListEntry, error) {
+	if mediaType != MediaTypeAnime && mediaType != MediaTypeManga {
+		return nil, fmt.Errorf("invalid mediaType provided: %s. Accepts only %s or %s", mediaType, MediaTypeAnime, MediaTypeManga)
+	}
+
+	document := malDocument{
+		MyInfo: malMyInfo{UserName: username, UserExportType: malExportTypeAnime},
+	}
+	statusIndex := 0
+	if mediaType == MediaTypeManga {
+		document.MyInfo.UserExportType = malExportTypeManga
+		statusIndex = 1
+	}
+
+	var missing []MediaListEntry
+	for _, entry := range entries {
+		if entry.Media.IDMal == nil || *entry.Media.IDMal == 0 {
+			missing = append(missing, entry)
+			continue
+		}
+
+		status := malStatuses[entry.Status][statusIndex]
+		countMALStatus(&document.MyInfo, entry.Status)
+
+		repeating := 0
+		if entry.Status == "REPEATING" {
+			repeating = 1
+		}
+
+		if mediaType == MediaTypeAnime {
+			document.Anime = append(document.Anime, malAnime{
+				ID:              *entry.Media.IDMal,
+				Title:           malCDATA{Value: malTitle(entry.Media)},
+				Type:            malSeriesTypes[entry.Media.Format],
+				Episodes:        intValue(entry.Media.Episodes),
+				WatchedEpisodes: intValue(entry.Progress),
+				StartDate:       malDate(entry.StartedAt),
+				FinishDate:      malDate(entry.CompletedAt),
+				Score:           malScore(entry.Score),
+				StorageValue:    "0.00",
+				Status:          status,
+				Comments:        malCDATA{Value: entry.Notes},
+				TimesWatched:    entry.Repeat,
+				Priority:        malPriority(entry.Priority),
+				Rewatching:      repeating,
+				Discuss:         1,
+				SNS:             "default",
+				UpdateOnImport:  1,
+			})
+		} else {
+			document.Manga = append(document.Manga, malManga{
+				ID:             *entry.Media.IDMal,
+				Title:          malCDATA{Value: malTitle(entry.Media)},
+				Volumes:        intValue(entry.Media.Volumes),
+				Chapters:       intValue(entry.Media.Chapters),
+				ReadVolumes:    intValue(entry.ProgressVolumes),
+				ReadChapters:   intValue(entry.Progress),
+				StartDate:      malDate(entry.StartedAt),
+				FinishDate:     malDate(entry.CompletedAt),
+				Score:          malScore(entry.Score),
+				Status:         status,
+				Comments:       malCDATA{Value: entry.Notes},
+				TimesRead:      entry.Repeat,
+				Priority:       malPriority(entry.Priority),
+				Rereading:      repeating,
+				Discuss:        1,
+				SNS:            "default",
+				UpdateOnImport: 1,
+			})
+		}
+	}
+
+	if mediaType == MediaTypeAnime {
+		document.MyInfo.Total = len(document.Anime)
+	} else {
+		document.MyInfo.TotalManga = len(document.Manga)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return missing, err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "\t")
+	if err := encoder.Encode(document); err != nil {
+		return missing, err
+	}
+	_, err := io.WriteString(w, "\n")
+	return missing, err
+}
+
+// ExportMALLists fetches the anime and manga lists of a user and writes them
+// to the two writers with ExportMAL.
+//
+// Returns:
+//   - The anime and manga entries that have no MyAnimeList ID.
+//   - An error if there's any issue fetching the lists or writing the files.
+func ExportMALLists(username string, anime io.Writer, manga io.Writer) ([]MediaListEntry, error) {
+	var missing []MediaListEntry
+	for _, export := range []struct {
+		mediaType string
+		w         io.Writer
+	}{
+		{MediaTypeAnime, anime},
+		{MediaTypeManga, manga},
+	} {
+		entries, err := GetMediaListCollection(username, export.mediaType)
+		if err != nil {
+			return missing, err
+		}
+
+		exportMissing, err := ExportMAL(export.w, username, export.mediaType, entries)
+		missing = append(missing, exportMissing...)
+		if err != nil {
+			return missing, err
+		}
+	}
+	return missing, nil
+}
+
+func countMALStatus(info *malMyInfo, status string) {
+	switch status {
+	case "CURRENT", "REPEATING":
+		if info.UserExportType == malExportTypeAnime {
+			info.Watching++
+		} else {
+			info.Reading++
+		}
+	case "COMPLETED":
+		info.Completed++
+	case "PAUSED":
+		info.OnHold++
+	case "DROPPED":
+		info.Dropped++
+	case "PLANNING":
+		if info.UserExportType == malExportTypeAnime {
+			info.PlanToWatch++
+		} else {
+			info.PlanToRead++
+		}
+	}
+}
+
+func malTitle(media Media) string {
+	if media.Title.Romaji != "" {
+		return media.Title.Romaji
+	}
+	return media.Title.English
+}
+
+func malDate(date FuzzyDate) string {
+	if date.IsZero() {
+		return malEmptyDate
+	}
+	return date.String()
+}
+
+// malScore converts a POINT_100 score to the 0-10 scale used by MyAnimeList.
+func malScore(score int) int {
+	return int(math.Round(float64(score) / 10))
+}
+
+func malPriority(priority int) string {
+	switch {
+	case priority <= 0:
+		return "LOW"
+	case priority < 5:
+		return "MEDIUM"
+	default:
+		return "HIGH"
+	}
+}
+
+func intValue(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
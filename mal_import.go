@@ -0,0 +1,316 @@
+package anilistgo
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ConflictKeepHigherProgress = "KEEP_HIGHER_PROGRESS"
+	ConflictOverwrite          = "OVERWRITE"
+	ConflictSkip               = "SKIP"
+
+	MediaByMALIDQuery = `
+    query ($idMal: Int, $type: MediaType) {
+        Media (idMal: $idMal, type: $type) {
+            id
+        }
+    }
+    `
+
+	MediaSearchByTypeQuery = `
+    query ($search: String, $type: MediaType) {
+        Media (search: $search, type: $type) {
+            id
+        }
+    }
+    `
+)
+
+// malImportStatuses maps the MyAnimeList status names, and the numeric
+// statuses used by older exports, to AniList list statuses.
+var malImportStatuses = map[string]string{
+	"watching":      "CURRENT",
+	"reading":       "CURRENT",
+	"completed":     "COMPLETED",
+	"on-hold":       "PAUSED",
+	"dropped":       "DROPPED",
+	"plan to watch": "PLANNING",
+	"plan to read":  "PLANNING",
+	"1":             "CURRENT",
+	"2":             "COMPLETED",
+	"3":             "PAUSED",
+	"4":             "DROPPED",
+	"6":             "PLANNING",
+}
+
+// MALEntry is a single entry of a MyAnimeList export, converted to AniList
+// conventions: Status is an AniList list status and Score uses the POINT_100
+// format. MediaID is the AniList ID of the media, set by ResolveMALEntries.
+type MALEntry struct {
+	MalID           int
+	MediaID         int
+	MediaType       string
+	Title           string
+	Status          string
+	Score           int
+	Progress        int
+	ProgressVolumes int
+	Repeat          int
+	Notes           string
+	StartedAt       FuzzyDate
+	CompletedAt     FuzzyDate
+}
+
+// ImportPlan is the result of PlanMALImport. Operations can be applied with
+// AuthenticatedAPI.ApplyOperations. Conflicts holds the entries that already
+// exist on the user's list and were left alone because of the conflict
+// policy, and Unresolved the entries without a matching AniList media.
+type ImportPlan struct {
+	Operations []SaveMediaListEntryOp
+	Conflicts  []MALEntry
+	Unresolved []MALEntry
+}
+
+// ParseMALExport reads a MyAnimeList export XML document, containing either
+// anime or manga entries.
+//
+// Usage:
+//
+//	file, err := os.Open("animelist.xml")
+//	entries, err := ParseMALExport(file)
+func ParseMALExport(r io.Reader) ([]MALEntry, error) {
+	var document malDocument
+	if err := xml.NewDecoder(r).Decode(&document); err != nil {
+		return nil, err
+	}
+
+	entries := make([]MALEntry, 0, len(document.Anime)+len(document.Manga))
+	for _, anime := range document.Anime {
+		entries = append(entries, MALEntry{
+			MalID:       anime.ID,
+			MediaType:   MediaTypeAnime,
+			Title:       strings.TrimSpace(anime.Title.Value),
+			Status:      malImportStatus(anime.Status, anime.Rewatching),
+			Score:       anime.Score * 10,
+			Progress:    anime.WatchedEpisodes,
+			Repeat:      anime.TimesWatched,
+			Notes:       strings.TrimSpace(anime.Comments.Value),
+			StartedAt:   parseMALDate(anime.StartDate),
+			CompletedAt: parseMALDate(anime.FinishDate),
+		})
+	}
+	for _, manga := range document.Manga {
+		entries = append(entries, MALEntry{
+			MalID:           manga.ID,
+			MediaType:       MediaTypeManga,
+			Title:           strings.TrimSpace(manga.Title.Value),
+			Status:          malImportStatus(manga.Status, manga.Rereading),
+			Score:           manga.Score * 10,
+			Progress:        manga.ReadChapters,
+			ProgressVolumes: manga.ReadVolumes,
+			Repeat:          manga.TimesRead,
+			Notes:           strings.TrimSpace(manga.Comments.Value),
+			StartedAt:       parseMALDate(manga.StartDate),
+			CompletedAt:     parseMALDate(manga.FinishDate),
+		})
+	}
+
+	return entries, nil
+}
+
+// ResolveMALEntries looks up the AniList media of every entry by its
+// MyAnimeList ID, falling back to a title search if AniList does not know the
+// ID. Requests are spaced by the given interval, which defaults to
+// DefaultMutationInterval. Entries that could not be resolved keep a MediaID
+// of 0.
+func ResolveMALEntries(ctx context.Context, entries []MALEntry, interval time.Duration) ([]MALEntry, error) {
+	if interval <= 0 {
+		interval = DefaultMutationInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	resolved := make([]MALEntry, len(entries))
+	copy(resolved, entries)
+
+	wait := func(first bool) error {
+		if first {
+			return ctx.Err()
+		}
+		select {
+		case <-ticker.C:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	first := true
+	for i := range resolved {
+		entry := &resolved[i]
+
+		if entry.MalID != 0 {
+			if err := wait(first); err != nil {
+				return resolved, err
+			}
+			first = false
+
+			media, err := fetchAnilistData(MediaByMALIDQuery, map[string]interface{}{
+				"idMal": entry.MalID,
+				"type":  entry.MediaType,
+			})
+			if err != nil {
+				return resolved, err
+			}
+			entry.MediaID = media.ID
+		}
+
+		if entry.MediaID == 0 && entry.Title != "" {
+			if err := wait(first); err != nil {
+				return resolved, err
+			}
+			first = false
+
+			media, err := fetchAnilistData(MediaSearchByTypeQuery, map[string]interface{}{
+				"search": entry.Title,
+				"type":   entry.MediaType,
+			})
+			if err != nil {
+				return resolved, err
+			}
+			entry.MediaID = media.ID
+		}
+	}
+
+	return resolved, nil
+}
+
+// PlanMALImport computes the operations needed to bring the resolved
+// MyAnimeList entries onto the user's current list. Entries that are not on
+// the list yet are always added. For entries that are, the policy decides:
+//   - ConflictKeepHigherProgress only overwrites the entry if the MyAnimeList
+//     progress is higher.
+//   - ConflictOverwrite always overwrites the entry.
+//   - ConflictSkip never touches existing entries.
+//
+// Entries whose status, score, progress and repeat count already match the
+// current list are left out of the plan entirely. An unknown policy is an
+// error.
+//
+// Usage:
+//
+//	entries, err := ParseMALExport(file)
+//	resolved, err := ResolveMALEntries(ctx, entries, 0)
+//	current, err := GetMediaListCollection("Ithilias", MediaTypeAnime)
+//	plan, err := PlanMALImport(resolved, current, ConflictKeepHigherProgress)
+//	applied, err := api.ApplyOperations(ctx, plan.Operations, ApplyOptions{CheckpointPath: "import.checkpoint"})
+func PlanMALImport(entries []MALEntry, current []MediaListEntry, policy string) (ImportPlan, error) {
+	if policy != ConflictKeepHigherProgress && policy != ConflictOverwrite && policy != ConflictSkip {
+		return ImportPlan{}, fmt.Errorf("invalid conflict policy: %s", policy)
+	}
+
+	existing := make(map[int]MediaListEntry, len(current))
+	for _, entry := range current {
+		existing[entry.MediaID] = entry
+	}
+
+	var plan ImportPlan
+	for _, entry := range entries {
+		if entry.MediaID == 0 {
+			plan.Unresolved = append(plan.Unresolved, entry)
+			continue
+		}
+
+		currentEntry, ok := existing[entry.MediaID]
+		if ok {
+			if malEntryMatches(entry, currentEntry) {
+				continue
+			}
+			if policy == ConflictSkip || (policy != ConflictOverwrite && entry.Progress <= intValue(currentEntry.Progress)) {
+				plan.Conflicts = append(plan.Conflicts, entry)
+				continue
+			}
+		}
+
+		plan.Operations = append(plan.Operations, entry.SaveOperation())
+	}
+
+	return plan, nil
+}
+
+// SaveOperation returns the operation that saves this entry on AniList.
+func (e MALEntry) SaveOperation() SaveMediaListEntryOp {
+	op := SaveMediaListEntryOp{
+		MediaID: e.MediaID,
+		Status:  e.Status,
+	}
+
+	progress, repeat := e.Progress, e.Repeat
+	op.Progress = &progress
+	op.Repeat = &repeat
+
+	if e.Score > 0 {
+		score := e.Score
+		op.Score = &score
+	}
+	if e.MediaType == MediaTypeManga {
+		volumes := e.ProgressVolumes
+		op.ProgressVolumes = &volumes
+	}
+	if e.Notes != "" {
+		notes := e.Notes
+		op.Notes = &notes
+	}
+	if !e.StartedAt.IsZero() {
+		startedAt := e.StartedAt
+		op.StartedAt = &startedAt
+	}
+	if !e.CompletedAt.IsZero() {
+		completedAt := e.CompletedAt
+		op.CompletedAt = &completedAt
+	}
+
+	return op
+}
+
+func malEntryMatches(entry MALEntry, current MediaListEntry) bool {
+	return entry.Status == current.Status &&
+		entry.Score == current.Score &&
+		entry.Progress == intValue(current.Progress) &&
+		(entry.MediaType != MediaTypeManga || entry.ProgressVolumes == intValue(current.ProgressVolumes)) &&
+		entry.Repeat == current.Repeat
+}
+
+func malImportStatus(status string, repeating int) string {
+	converted := malImportStatuses[strings.ToLower(strings.TrimSpace(status))]
+	if converted == "CURRENT" && repeating == 1 {
+		return "REPEATING"
+	}
+	return converted
+}
+
+// parseMALDate parses a YYYY-MM-DD date where unknown parts are zero.
+func parseMALDate(date string) FuzzyDate {
+	var fuzzy FuzzyDate
+	parts := strings.Split(strings.TrimSpace(date), "-")
+	if len(parts) != 3 {
+		return fuzzy
+	}
+
+	targets := []**int{&fuzzy.Year, &fuzzy.Month, &fuzzy.Day}
+	for i, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil || value == 0 {
+			continue
+		}
+		*targets[i] = &value
+	}
+	return fuzzy
+}
@@ -0,0 +1,99 @@
+package anilistgo
+
+import (
+	"strings"
+	"testing"
+)
+
+const testMALExport = `<?xml version="1.0" encoding="UTF-8" ?>
+<myanimelist>
+	<myinfo>
+		<user_name>Ithilias</user_name>
+		<user_export_type>1</user_export_type>
+	</myinfo>
+	<anime>
+		<series_animedb_id>16498</series_animedb_id>
+		<series_title><![CDATA[Shingeki no Kyojin]]></series_title>
+		<my_watched_episodes>25</my_watched_episodes>
+		<my_start_date>2013-04-00</my_start_date>
+		<my_finish_date>0000-00-00</my_finish_date>
+		<my_score>9</my_score>
+		<my_status>Completed</my_status>
+		<my_times_watched>1</my_times_watched>
+		<my_comments><![CDATA[]]></my_comments>
+	</anime>
+	<anime>
+		<series_animedb_id>21</series_animedb_id>
+		<series_title><![CDATA[One Piece]]></series_title>
+		<my_watched_episodes>100</my_watched_episodes>
+		<my_score>0</my_score>
+		<my_status>Watching</my_status>
+		<my_rewatching>1</my_rewatching>
+	</anime>
+	<anime>
+		<series_animedb_id>1</series_animedb_id>
+		<series_title><![CDATA[Cowboy Bebop]]></series_title>
+		<my_status>Plan to Watch</my_status>
+	</anime>
+</myanimelist>`
+
+func TestParseMALExport(t *testing.T) {
+	entries, err := ParseMALExport(strings.NewReader(testMALExport))
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries but got %d", len(entries))
+	}
+
+	aot := entries[0]
+	if aot.Status != "COMPLETED" || aot.Score != 90 || aot.Progress != 25 || aot.MediaType != MediaTypeAnime {
+		t.Errorf("expected converted status, score and progress but got %+v", aot)
+	}
+	if aot.StartedAt.String() != "2013-04-00" || !aot.CompletedAt.IsZero() {
+		t.Errorf("expected fuzzy dates but got %s and %s", aot.StartedAt, aot.CompletedAt)
+	}
+	if entries[1].Status != "REPEATING" {
+		t.Errorf("expected rewatching entry to be REPEATING but got %s", entries[1].Status)
+	}
+}
+
+func TestPlanMALImport(t *testing.T) {
+	entries, err := ParseMALExport(strings.NewReader(testMALExport))
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	entries[0].MediaID = 16498
+	entries[1].MediaID = 21
+
+	twentyFive, twoHundred := 25, 200
+	current := []MediaListEntry{
+		{MediaID: 16498, Status: "COMPLETED", Score: 90, Progress: &twentyFive, Repeat: 1},
+		{MediaID: 21, Status: "CURRENT", Progress: &twoHundred},
+	}
+
+	plan, err := PlanMALImport(entries, current, ConflictKeepHigherProgress)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if len(plan.Operations) != 0 || len(plan.Conflicts) != 1 || len(plan.Unresolved) != 1 {
+		t.Errorf("expected lower progress to be kept as a conflict but got %+v", plan)
+	}
+
+	plan, _ = PlanMALImport(entries, current, ConflictOverwrite)
+	if len(plan.Operations) != 1 || plan.Operations[0].MediaID != 21 || *plan.Operations[0].Progress != 100 {
+		t.Errorf("expected the differing entry to be overwritten but got %+v", plan)
+	}
+	if plan.Operations[0].Score != nil {
+		t.Errorf("expected no score for an unscored entry but got %d", *plan.Operations[0].Score)
+	}
+
+	plan, _ = PlanMALImport(entries, nil, ConflictSkip)
+	if len(plan.Operations) != 2 || len(plan.Conflicts) != 0 {
+		t.Errorf("expected new entries to be added regardless of the policy but got %+v", plan)
+	}
+
+	if _, err := PlanMALImport(entries, current, "KEEP_HIGHER"); err == nil {
+		t.Error("expected an error for an unknown policy")
+	}
+}
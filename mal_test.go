@@ -0,0 +1,69 @@
+package anilistgo
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestExportMAL(t *testing.T) {
+	malID, episodes, progress, year, month := 16498, 25, 3, 2013, 4
+	entries := []MediaListEntry{
+		{
+			MediaID:   16498,
+			Status:    "REPEATING",
+			Score:     85,
+			Progress:  &progress,
+			Repeat:    2,
+			Notes:     "Rewatching <with> friends",
+			StartedAt: FuzzyDate{Year: &year, Month: &month},
+			Media: Media{
+				ID:       16498,
+				IDMal:    &malID,
+				Format:   "TV",
+				Title:    MediaTitle{Romaji: "Shingeki no Kyojin"},
+				Episodes: &episodes,
+			},
+		},
+		{MediaID: 1, Status: "PLANNING", Media: Media{ID: 1}},
+	}
+
+	var buf bytes.Buffer
+	missing, err := ExportMAL(&buf, "Ithilias", MediaTypeAnime, entries)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if len(missing) != 1 || missing[0].MediaID != 1 {
+		t.Errorf("expected the entry without idMal to be reported but got %v", missing)
+	}
+
+	if !strings.Contains(buf.String(), "<![CDATA[Shingeki no Kyojin]]>") {
+		t.Errorf("expected title as CDATA but got:\n%s", buf.String())
+	}
+
+	var document malDocument
+	if err := xml.Unmarshal(buf.Bytes(), &document); err != nil {
+		t.Fatalf("expected valid XML but got: %v", err)
+	}
+	if len(document.Anime) != 1 {
+		t.Fatalf("expected 1 anime but got %d", len(document.Anime))
+	}
+
+	anime := document.Anime[0]
+	if anime.ID != 16498 || anime.Status != "Watching" || anime.Rewatching != 1 || anime.TimesWatched != 2 {
+		t.Errorf("expected repeating entry to be exported as rewatching but got %+v", anime)
+	}
+	if anime.Score != 9 || anime.WatchedEpisodes != 3 || anime.Episodes != 25 || anime.Type != "TV" {
+		t.Errorf("expected score, progress and series data to be mapped but got %+v", anime)
+	}
+	if anime.StartDate != "2013-04-00" || anime.FinishDate != "0000-00-00" {
+		t.Errorf("expected fuzzy dates to be mapped but got %s and %s", anime.StartDate, anime.FinishDate)
+	}
+	if anime.Comments.Value != "Rewatching <with> friends" {
+		t.Errorf("expected notes as comments but got %q", anime.Comments.Value)
+	}
+	if document.MyInfo.Watching != 1 || document.MyInfo.Total != 1 {
+		t.Errorf("expected totals in myinfo but got %+v", document.MyInfo)
+	}
+}
@@ -0,0 +1,811 @@
+package anilistgo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	NotificationTypeAiring                  = "AIRING"
+	NotificationTypeActivityMessage         = "ACTIVITY_MESSAGE"
+	NotificationTypeActivityReply           = "ACTIVITY_REPLY"
+	NotificationTypeFollowing               = "FOLLOWING"
+	NotificationTypeActivityMention         = "ACTIVITY_MENTION"
+	NotificationTypeThreadCommentMention    = "THREAD_COMMENT_MENTION"
+	NotificationTypeThreadSubscribed        = "THREAD_SUBSCRIBED"
+	NotificationTypeThreadCommentReply      = "THREAD_COMMENT_REPLY"
+	NotificationTypeActivityLike            = "ACTIVITY_LIKE"
+	NotificationTypeActivityReplyLike       = "ACTIVITY_REPLY_LIKE"
+	NotificationTypeThreadLike              = "THREAD_LIKE"
+	NotificationTypeThreadCommentLike       = "THREAD_COMMENT_LIKE"
+	NotificationTypeActivityReplySubscribed = "ACTIVITY_REPLY_SUBSCRIBED"
+	NotificationTypeRelatedMediaAddition    = "RELATED_MEDIA_ADDITION"
+	NotificationTypeMediaDataChange         = "MEDIA_DATA_CHANGE"
+	NotificationTypeMediaMerge              = "MEDIA_MERGE"
+	NotificationTypeMediaDeletion           = "MEDIA_DELETION"
+
+	DefaultNotificationPollInterval = time.Minute
+
+	NotificationsQuery = `
+    query ($page: Int, $perPage: Int, $typeIn: [NotificationType], $resetNotificationCount: Boolean) {
+      Page (page: $page, perPage: $perPage) {
+        pageInfo {
+          total
+          perPage
+          currentPage
+          lastPage
+          hasNextPage
+        }
+        notifications (type_in: $typeIn, resetNotificationCount: $resetNotificationCount) {
+          __typename
+          ... on AiringNotification {
+            id
+            type
+            animeId
+            episode
+            contexts
+            createdAt
+            media {
+              id
+              type
+              title {
+                romaji
+                english
+                native
+              }
+            }
+          }
+          ... on FollowingNotification {
+            id
+            type
+            userId
+            context
+            createdAt
+            user {
+              id
+              name
+            }
+          }
+          ... on ActivityMessageNotification {
+            id
+            type
+            userId
+            activityId
+            context
+            createdAt
+            user {
+              id
+              name
+            }
+          }
+          ... on ActivityMentionNotification {
+            id
+            type
+            userId
+            activityId
+            context
+            createdAt
+            user {
+              id
+              name
+            }
+          }
+          ... on ActivityReplyNotification {
+            id
+            type
+            userId
+            activityId
+            context
+            createdAt
+            user {
+              id
+              name
+            }
+          }
+          ... on ActivityReplySubscribedNotification {
+            id
+            type
+            userId
+            activityId
+            context
+            createdAt
+            user {
+              id
+              name
+            }
+          }
+          ... on ActivityLikeNotification {
+            id
+            type
+            userId
+            activityId
+            context
+            createdAt
+            user {
+              id
+              name
+            }
+          }
+          ... on ActivityReplyLikeNotification {
+            id
+            type
+            userId
+            activityId
+            context
+            createdAt
+            user {
+              id
+              name
+            }
+          }
+          ... on ThreadCommentMentionNotification {
+            id
+            type
+            userId
+            commentId
+            context
+            createdAt
+            thread {
+              id
+              title
+            }
+            user {
+              id
+              name
+            }
+          }
+          ... on ThreadCommentReplyNotification {
+            id
+            type
+            userId
+            commentId
+            context
+            createdAt
+            thread {
+              id
+              title
+            }
+            user {
+              id
+              name
+            }
+          }
+          ... on ThreadCommentSubscribedNotification {
+            id
+            type
+            userId
+            commentId
+            context
+            createdAt
+            thread {
+              id
+              title
+            }
+            user {
+              id
+              name
+            }
+          }
+          ... on ThreadCommentLikeNotification {
+            id
+            type
+            userId
+            commentId
+            context
+            createdAt
+            thread {
+              id
+              title
+            }
+            user {
+              id
+              name
+            }
+          }
+          ... on ThreadLikeNotification {
+            id
+            type
+            userId
+            threadId
+            context
+            createdAt
+            thread {
+              id
+              title
+            }
+            user {
+              id
+              name
+            }
+          }
+          ... on RelatedMediaAdditionNotification {
+            id
+            type
+            mediaId
+            context
+            createdAt
+            media {
+              id
+              type
+              title {
+                romaji
+                english
+                native
+              }
+            }
+          }
+          ... on MediaDataChangeNotification {
+            id
+            type
+            mediaId
+            context
+            reason
+            createdAt
+            media {
+              id
+              type
+              title {
+                romaji
+                english
+                native
+              }
+            }
+          }
+          ... on MediaMergeNotification {
+            id
+            type
+            mediaId
+            deletedMediaTitles
+            context
+            reason
+            createdAt
+            media {
+              id
+              type
+              title {
+                romaji
+                english
+                native
+              }
+            }
+          }
+          ... on MediaDeletionNotification {
+            id
+            type
+            deletedMediaTitle
+            context
+            reason
+            createdAt
+          }
+          ... on MediaSubmissionUpdate {
+            id
+            type
+            createdAt
+          }
+          ... on StaffSubmissionUpdate {
+            id
+            type
+            createdAt
+          }
+          ... on CharacterSubmissionUpdate {
+            id
+            type
+            createdAt
+          }
+        }
+      }
+    }
+    `
+)
+
+// Notification is implemented by every notification type AniList can send,
+// such as AiringNotification or ActivityReplyNotification. Use a type switch
+// to access the fields of a specific notification type.
+type Notification interface {
+	NotificationID() int
+	NotificationCreatedAt() int64
+}
+
+// NotificationUnion decodes any of the notification types returned by
+// AniList, based on the __typename field of the response.
+type NotificationUnion struct {
+	Notification Notification
+}
+
+type NotificationThread struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+// NotificationOptions controls which notifications are fetched. Types takes
+// the NotificationType constants and fetches all types if it is empty.
+// ResetNotificationCount marks the notifications as read on AniList.
+type NotificationOptions struct {
+	Types                  []string
+	ResetNotificationCount bool
+}
+
+type AiringNotification struct {
+	ID        int      `json:"id"`
+	Type      string   `json:"type"`
+	AnimeID   int      `json:"animeId"`
+	Episode   int      `json:"episode"`
+	Contexts  []string `json:"contexts"`
+	CreatedAt int64    `json:"createdAt"`
+	Media     Media    `json:"media"`
+}
+
+type FollowingNotification struct {
+	ID        int      `json:"id"`
+	Type      string   `json:"type"`
+	UserID    int      `json:"userId"`
+	Context   string   `json:"context"`
+	CreatedAt int64    `json:"createdAt"`
+	User      UserInfo `json:"user"`
+}
+
+type ActivityMessageNotification struct {
+	ID         int      `json:"id"`
+	Type       string   `json:"type"`
+	UserID     int      `json:"userId"`
+	ActivityID int      `json:"activityId"`
+	Context    string   `json:"context"`
+	CreatedAt  int64    `json:"createdAt"`
+	User       UserInfo `json:"user"`
+}
+
+type ActivityMentionNotification struct {
+	ID         int      `json:"id"`
+	Type       string   `json:"type"`
+	UserID     int      `json:"userId"`
+	ActivityID int      `json:"activityId"`
+	Context    string   `json:"context"`
+	CreatedAt  int64    `json:"createdAt"`
+	User       UserInfo `json:"user"`
+}
+
+type ActivityReplyNotification struct {
+	ID         int      `json:"id"`
+	Type       string   `json:"type"`
+	UserID     int      `json:"userId"`
+	ActivityID int      `json:"activityId"`
+	Context    string   `json:"context"`
+	CreatedAt  int64    `json:"createdAt"`
+	User       UserInfo `json:"user"`
+}
+
+type ActivityReplySubscribedNotification struct {
+	ID         int      `json:"id"`
+	Type       string   `json:"type"`
+	UserID     int      `json:"userId"`
+	ActivityID int      `json:"activityId"`
+	Context    string   `json:"context"`
+	CreatedAt  int64    `json:"createdAt"`
+	User       UserInfo `json:"user"`
+}
+
+type ActivityLikeNotification struct {
+	ID         int      `json:"id"`
+	Type       string   `json:"type"`
+	UserID     int      `json:"userId"`
+	ActivityID int      `json:"activityId"`
+	Context    string   `json:"context"`
+	CreatedAt  int64    `json:"createdAt"`
+	User       UserInfo `json:"user"`
+}
+
+type ActivityReplyLikeNotification struct {
+	ID         int      `json:"id"`
+	Type       string   `json:"type"`
+	UserID     int      `json:"userId"`
+	ActivityID int      `json:"activityId"`
+	Context    string   `json:"context"`
+	CreatedAt  int64    `json:"createdAt"`
+	User       UserInfo `json:"user"`
+}
+
+type ThreadCommentMentionNotification struct {
+	ID        int                `json:"id"`
+	Type      string             `json:"type"`
+	UserID    int                `json:"userId"`
+	CommentID int                `json:"commentId"`
+	Context   string             `json:"context"`
+	CreatedAt int64              `json:"createdAt"`
+	Thread    NotificationThread `json:"thread"`
+	User      UserInfo           `json:"user"`
+}
+
+type ThreadCommentReplyNotification struct {
+	ID        int                `json:"id"`
+	Type      string             `json:"type"`
+	UserID    int                `json:"userId"`
+	CommentID int                `json:"commentId"`
+	Context   string             `json:"context"`
+	CreatedAt int64              `json:"createdAt"`
+	Thread    NotificationThread `json:"thread"`
+	User      UserInfo           `json:"user"`
+}
+
+type ThreadCommentSubscribedNotification struct {
+	ID        int                `json:"id"`
+	Type      string             `json:"type"`
+	UserID    int                `json:"userId"`
+	CommentID int                `json:"commentId"`
+	Context   string             `json:"context"`
+	CreatedAt int64              `json:"createdAt"`
+	Thread    NotificationThread `json:"thread"`
+	User      UserInfo           `json:"user"`
+}
+
+type ThreadCommentLikeNotification struct {
+	ID        int                `json:"id"`
+	Type      string             `json:"type"`
+	UserID    int                `json:"userId"`
+	CommentID int                `json:"commentId"`
+	Context   string             `json:"context"`
+	CreatedAt int64              `json:"createdAt"`
+	Thread    NotificationThread `json:"thread"`
+	User      UserInfo           `json:"user"`
+}
+
+type ThreadLikeNotification struct {
+	ID        int                `json:"id"`
+	Type      string             `json:"type"`
+	UserID    int                `json:"userId"`
+	ThreadID  int                `json:"threadId"`
+	Context   string             `json:"context"`
+	CreatedAt int64              `json:"createdAt"`
+	Thread    NotificationThread `json:"thread"`
+	User      UserInfo           `json:"user"`
+}
+
+type RelatedMediaAdditionNotification struct {
+	ID        int    `json:"id"`
+	Type      string `json:"type"`
+	MediaID   int    `json:"mediaId"`
+	Context   string `json:"context"`
+	CreatedAt int64  `json:"createdAt"`
+	Media     Media  `json:"media"`
+}
+
+type MediaDataChangeNotification struct {
+	ID        int    `json:"id"`
+	Type      string `json:"type"`
+	MediaID   int    `json:"mediaId"`
+	Context   string `json:"context"`
+	Reason    string `json:"reason"`
+	CreatedAt int64  `json:"createdAt"`
+	Media     Media  `json:"media"`
+}
+
+type MediaMergeNotification struct {
+	ID                 int      `json:"id"`
+	Type               string   `json:"type"`
+	MediaID            int      `json:"mediaId"`
+	DeletedMediaTitles []string `json:"deletedMediaTitles"`
+	Context            string   `json:"context"`
+	Reason             string   `json:"reason"`
+	CreatedAt          int64    `json:"createdAt"`
+	Media              Media    `json:"media"`
+}
+
+type MediaDeletionNotification struct {
+	ID                int    `json:"id"`
+	Type              string `json:"type"`
+	DeletedMediaTitle string `json:"deletedMediaTitle"`
+	Context           string `json:"context"`
+	Reason            string `json:"reason"`
+	CreatedAt         int64  `json:"createdAt"`
+}
+
+// UnknownNotification is a notification type the package does not model,
+// such as MediaSubmissionUpdate. Raw holds the notification as returned by
+// AniList, and the ID is only set for types the query selects it for.
+type UnknownNotification struct {
+	Typename  string          `json:"__typename"`
+	ID        int             `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt int64           `json:"createdAt"`
+	Raw       json.RawMessage `json:"-"`
+}
+
+func (n AiringNotification) NotificationID() int                           { return n.ID }
+func (n AiringNotification) NotificationCreatedAt() int64                  { return n.CreatedAt }
+func (n FollowingNotification) NotificationID() int                        { return n.ID }
+func (n FollowingNotification) NotificationCreatedAt() int64               { return n.CreatedAt }
+func (n ActivityMessageNotification) NotificationID() int                  { return n.ID }
+func (n ActivityMessageNotification) NotificationCreatedAt() int64         { return n.CreatedAt }
+func (n ActivityMentionNotification) NotificationID() int                  { return n.ID }
+func (n ActivityMentionNotification) NotificationCreatedAt() int64         { return n.CreatedAt }
+func (n ActivityReplyNotification) NotificationID() int                    { return n.ID }
+func (n ActivityReplyNotification) NotificationCreatedAt() int64           { return n.CreatedAt }
+func (n ActivityReplySubscribedNotification) NotificationID() int          { return n.ID }
+func (n ActivityReplySubscribedNotification) NotificationCreatedAt() int64 { return n.CreatedAt }
+func (n ActivityLikeNotification) NotificationID() int                     { return n.ID }
+func (n ActivityLikeNotification) NotificationCreatedAt() int64            { return n.CreatedAt }
+func (n ActivityReplyLikeNotification) NotificationID() int                { return n.ID }
+func (n ActivityReplyLikeNotification) NotificationCreatedAt() int64       { return n.CreatedAt }
+func (n ThreadCommentMentionNotification) NotificationID() int             { return n.ID }
+func (n ThreadCommentMentionNotification) NotificationCreatedAt() int64    { return n.CreatedAt }
+func (n ThreadCommentReplyNotification) NotificationID() int               { return n.ID }
+func (n ThreadCommentReplyNotification) NotificationCreatedAt() int64      { return n.CreatedAt }
+func (n ThreadCommentSubscribedNotification) NotificationID() int          { return n.ID }
+func (n ThreadCommentSubscribedNotification) NotificationCreatedAt() int64 { return n.CreatedAt }
+func (n ThreadCommentLikeNotification) NotificationID() int                { return n.ID }
+func (n ThreadCommentLikeNotification) NotificationCreatedAt() int64       { return n.CreatedAt }
+func (n ThreadLikeNotification) NotificationID() int                       { return n.ID }
+func (n ThreadLikeNotification) NotificationCreatedAt() int64              { return n.CreatedAt }
+func (n RelatedMediaAdditionNotification) NotificationID() int             { return n.ID }
+func (n RelatedMediaAdditionNotification) NotificationCreatedAt() int64    { return n.CreatedAt }
+func (n MediaDataChangeNotification) NotificationID() int                  { return n.ID }
+func (n MediaDataChangeNotification) NotificationCreatedAt() int64         { return n.CreatedAt }
+func (n MediaMergeNotification) NotificationID() int                       { return n.ID }
+func (n MediaMergeNotification) NotificationCreatedAt() int64              { return n.CreatedAt }
+func (n MediaDeletionNotification) NotificationID() int                    { return n.ID }
+func (n MediaDeletionNotification) NotificationCreatedAt() int64           { return n.CreatedAt }
+func (n UnknownNotification) NotificationID() int                          { return n.ID }
+func (n UnknownNotification) NotificationCreatedAt() int64                 { return n.CreatedAt }
+
+func (u *NotificationUnion) UnmarshalJSON(data []byte) error {
+	var typename struct {
+		Typename string `json:"__typename"`
+	}
+	if err := json.Unmarshal(data, &typename); err != nil {
+		return err
+	}
+
+	switch typename.Typename {
+	case "AiringNotification":
+		var notification AiringNotification
+		if err := json.Unmarshal(data, &notification); err != nil {
+			return err
+		}
+		u.Notification = notification
+	case "FollowingNotification":
+		var notification FollowingNotification
+		if err := json.Unmarshal(data, &notification); err != nil {
+			return err
+		}
+		u.Notification = notification
+	case "ActivityMessageNotification":
+		var notification ActivityMessageNotification
+		if err := json.Unmarshal(data, &notification); err != nil {
+			return err
+		}
+		u.Notification = notification
+	case "ActivityMentionNotification":
+		var notification ActivityMentionNotification
+		if err := json.Unmarshal(data, &notification); err != nil {
+			return err
+		}
+		u.Notification = notification
+	case "ActivityReplyNotification":
+		var notification ActivityReplyNotification
+		if err := json.Unmarshal(data, &notification); err != nil {
+			return err
+		}
+		u.Notification = notification
+	case "ActivityReplySubscribedNotification":
+		var notification ActivityReplySubscribedNotification
+		if err := json.Unmarshal(data, &notification); err != nil {
+			return err
+		}
+		u.Notification = notification
+	case "ActivityLikeNotification":
+		var notification ActivityLikeNotification
+		if err := json.Unmarshal(data, &notification); err != nil {
+			return err
+		}
+		u.Notification = notification
+	case "ActivityReplyLikeNotification":
+		var notification ActivityReplyLikeNotification
+		if err := json.Unmarshal(data, &notification); err != nil {
+			return err
+		}
+		u.Notification = notification
+	case "ThreadCommentMentionNotification":
+		var notification ThreadCommentMentionNotification
+		if err := json.Unmarshal(data, &notification); err != nil {
+			return err
+		}
+		u.Notification = notification
+	case "ThreadCommentReplyNotification":
+		var notification ThreadCommentReplyNotification
+		if err := json.Unmarshal(data, &notification); err != nil {
+			return err
+		}
+		u.Notification = notification
+	case "ThreadCommentSubscribedNotification":
+		var notification ThreadCommentSubscribedNotification
+		if err := json.Unmarshal(data, &notification); err != nil {
+			return err
+		}
+		u.Notification = notification
+	case "ThreadCommentLikeNotification":
+		var notification ThreadCommentLikeNotification
+		if err := json.Unmarshal(data, &notification); err != nil {
+			return err
+		}
+		u.Notification = notification
+	case "ThreadLikeNotification":
+		var notification ThreadLikeNotification
+		if err := json.Unmarshal(data, &notification); err != nil {
+			return err
+		}
+		u.Notification = notification
+	case "RelatedMediaAdditionNotification":
+		var notification RelatedMediaAdditionNotification
+		if err := json.Unmarshal(data, &notification); err != nil {
+			return err
+		}
+		u.Notification = notification
+	case "MediaDataChangeNotification":
+		var notification MediaDataChangeNotification
+		if err := json.Unmarshal(data, &notification); err != nil {
+			return err
+		}
+		u.Notification = notification
+	case "MediaMergeNotification":
+		var notification MediaMergeNotification
+		if err := json.Unmarshal(data, &notification); err != nil {
+			return err
+		}
+		u.Notification = notification
+	case "MediaDeletionNotification":
+		var notification MediaDeletionNotification
+		if err := json.Unmarshal(data, &notification); err != nil {
+			return err
+		}
+		u.Notification = notification
+	default:
+		notification := UnknownNotification{Typename: typename.Typename, Raw: append(json.RawMessage(nil), data...)}
+		if err := json.Unmarshal(data, &notification); err != nil {
+			return err
+		}
+		u.Notification = notification
+	}
+
+	return nil
+}
+
+// GetNotifications retrieves one page of the authenticated user's
+// notifications, newest first.
+//
+// Parameters:
+//   - options: The NotificationOptions to apply, to filter by type or to reset
+//     the unread notification count.
+//   - page: The page to fetch, starting at 1.
+//   - perPage: The number of notifications per page, at most 50.
+//
+// Returns:
+//   - A slice of Notification values, each being one of the notification
+//     structs such as AiringNotification or FollowingNotification.
+//   - The PageInfo of the fetched page, to know whether there is a next page.
+//   - An error if there's any issue fetching the data.
+//
+// Usage:
+//
+//	notifications, _, err := api.GetNotifications(NotificationOptions{ResetNotificationCount: true}, 1, PerPage)
+//	for _, notification := range notifications {
+//	    if airing, ok := notification.(AiringNotification); ok {
+//	        fmt.Printf("Episode %d of %s aired\n", airing.Episode, airing.Media.Title.Romaji)
+//	    }
+//	}
+func (api *AuthenticatedAPI) GetNotifications(options NotificationOptions, page int, perPage int) ([]Notification, PageInfo, error) {
+	variables := map[string]interface{}{
+		"page":                   page,
+		"perPage":                perPage,
+		"resetNotificationCount": options.ResetNotificationCount,
+	}
+	if len(options.Types) > 0 {
+		variables["typeIn"] = options.Types
+	}
+
+	data, err := sendRequest(BaseAPIURL, NotificationsQuery, variables, api.AccessToken)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	if data.Data.Page == nil {
+		return nil, PageInfo{}, nil
+	}
+
+	notifications := make([]Notification, 0, len(data.Data.Page.Notifications))
+	for _, notification := range data.Data.Page.Notifications {
+		notifications = append(notifications, notification.Notification)
+	}
+	return notifications, data.Data.Page.PageInfo, nil
+}
+
+// StreamNotifications polls the authenticated user's notifications every
+// interval and sends new ones to the channel, oldest first, until the context
+// is cancelled. Notifications that already exist when the stream starts are
+// not sent. An interval of 0 uses DefaultNotificationPollInterval.
+//
+// StreamNotifications returns the context's error once it is done, or the
+// first error encountered while polling.
+//
+// Usage:
+//
+//	notifications := make(chan Notification)
+//	go func() {
+//	    for notification := range notifications {
+//	        fmt.Printf("%T\n", notification)
+//	    }
+//	}()
+//	err := api.StreamNotifications(ctx, NotificationOptions{}, time.Minute, notifications)
+func (api *AuthenticatedAPI) StreamNotifications(ctx context.Context, options NotificationOptions, interval time.Duration, notifications chan<- Notification) error {
+	fetchPage := func(page int) ([]Notification, PageInfo, error) {
+		return api.GetNotifications(options, page, MaxPerPage)
+	}
+	return streamNotifications(ctx, fetchPage, interval, notifications)
+}
+
+func streamNotifications(ctx context.Context, fetchPage func(page int) ([]Notification, PageInfo, error), interval time.Duration, notifications chan<- Notification) error {
+	if interval <= 0 {
+		interval = DefaultNotificationPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// The first poll only records the newest ID as the baseline. lastID stays
+	// 0 if the user has no notifications yet, so that every later one is new.
+	lastID := 0
+	initialized := false
+	for {
+		fresh, newestID, err := fetchNotificationsSince(fetchPage, lastID, !initialized)
+		if err != nil {
+			return err
+		}
+
+		if initialized {
+			for i := len(fresh) - 1; i >= 0; i-- {
+				select {
+				case notifications <- fresh[i]:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+		if newestID > lastID {
+			lastID = newestID
+		}
+		initialized = true
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// fetchNotificationsSince returns the notifications newer than lastID, newest
+// first, along with the highest ID seen. When baseline is set, only the first
+// page is fetched to find the newest ID.
+func fetchNotificationsSince(fetchPage func(page int) ([]Notification, PageInfo, error), lastID int, baseline bool) ([]Notification, int, error) {
+	var fresh []Notification
+	newestID := lastID
+
+	for page := 1; ; page++ {
+		notifications, pageInfo, err := fetchPage(page)
+		if err != nil {
+			return nil, newestID, err
+		}
+
+		for _, notification := range notifications {
+			if notification.NotificationID() > newestID {
+				newestID = notification.NotificationID()
+			}
+			if !baseline && notification.NotificationID() <= lastID {
+				return fresh, newestID, nil
+			}
+			fresh = append(fresh, notification)
+		}
+
+		if baseline || !pageInfo.HasNextPage {
+			return fresh, newestID, nil
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package anilistgo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNotificationUnionUnmarshal(t *testing.T) {
+	body := `{"notifications": [
+		{"__typename": "AiringNotification", "id": 1, "episode": 5, "media": {"id": 21}},
+		{"__typename": "ThreadLikeNotification", "id": 2, "threadId": 3, "thread": {"id": 3, "title": "Episode 5 discussion"}},
+		{"__typename": "MediaDeletionNotification", "id": 4, "deletedMediaTitle": "One Piece"},
+		{"__typename": "MediaSubmissionUpdate", "id": 5, "type": "MEDIA_SUBMISSION_UPDATE", "createdAt": 1700000000}
+	]}`
+
+	var page PageData
+	if err := json.Unmarshal([]byte(body), &page); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(page.Notifications) != 4 {
+		t.Fatalf("expected 4 notifications but got %d", len(page.Notifications))
+	}
+	if airing, ok := page.Notifications[0].Notification.(AiringNotification); !ok || airing.Episode != 5 || airing.Media.ID != 21 {
+		t.Errorf("expected airing notification for episode 5 but got %#v", page.Notifications[0].Notification)
+	}
+	if like, ok := page.Notifications[1].Notification.(ThreadLikeNotification); !ok || like.Thread.Title != "Episode 5 discussion" {
+		t.Errorf("expected thread like notification but got %#v", page.Notifications[1].Notification)
+	}
+	if deletion, ok := page.Notifications[2].Notification.(MediaDeletionNotification); !ok || deletion.NotificationID() != 4 {
+		t.Errorf("expected media deletion notification but got %#v", page.Notifications[2].Notification)
+	}
+	if unknown, ok := page.Notifications[3].Notification.(UnknownNotification); !ok || unknown.NotificationID() != 5 || unknown.Typename != "MediaSubmissionUpdate" {
+		t.Errorf("expected an unknown notification with its ID and type but got %#v", page.Notifications[3].Notification)
+	}
+}
+
+func testNotifications(ids ...int) []Notification {
+	notifications := make([]Notification, len(ids))
+	for i, id := range ids {
+		notifications[i] = AiringNotification{ID: id}
+	}
+	return notifications
+}
+
+func TestFetchNotificationsSince(t *testing.T) {
+	pages := [][]Notification{testNotifications(9, 8, 7), testNotifications(6, 5)}
+	fetchPage := func(page int) ([]Notification, PageInfo, error) {
+		return pages[page-1], PageInfo{HasNextPage: page < len(pages)}, nil
+	}
+
+	fresh, newestID, err := fetchNotificationsSince(fetchPage, 0, true)
+	if err != nil || newestID != 9 || len(fresh) != 3 {
+		t.Errorf("expected the baseline to only read the first page but got %d notifications, newest %d, %v", len(fresh), newestID, err)
+	}
+
+	fresh, newestID, err = fetchNotificationsSince(fetchPage, 6, false)
+	if err != nil || newestID != 9 || len(fresh) != 3 || fresh[2].NotificationID() != 7 {
+		t.Errorf("expected notifications 9 to 7 but got %v, newest %d, %v", fresh, newestID, err)
+	}
+
+	fresh, _, err = fetchNotificationsSince(fetchPage, 0, false)
+	if err != nil || len(fresh) != 5 {
+		t.Errorf("expected every notification to be new after an empty baseline but got %v, %v", fresh, err)
+	}
+}
+
+func TestStreamNotificationsAfterEmptyBaseline(t *testing.T) {
+	polls := [][]Notification{nil, testNotifications(2, 1), testNotifications(3, 2, 1)}
+	poll := 0
+	fetchPage := func(page int) ([]Notification, PageInfo, error) {
+		notifications := polls[min(poll, len(polls)-1)]
+		poll++
+		return notifications, PageInfo{}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	notifications := make(chan Notification)
+	done := make(chan error, 1)
+	go func() {
+		done <- streamNotifications(ctx, fetchPage, time.Millisecond, notifications)
+	}()
+
+	var received []int
+	for len(received) < 3 {
+		select {
+		case notification := <-notifications:
+			received = append(received, notification.NotificationID())
+		case <-time.After(time.Second):
+			t.Fatalf("expected 3 notifications but got %v", received)
+		}
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled but got %v", err)
+	}
+
+	expected := []int{1, 2, 3}
+	for i := range expected {
+		if received[i] != expected[i] {
+			t.Errorf("expected notifications %v oldest first but got %v", expected, received)
+			break
+		}
+	}
+}
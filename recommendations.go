@@ -0,0 +1,278 @@
+package anilistgo
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	DefaultSuggestMinScore = 80
+	DefaultSuggestPerTitle = 10
+	DefaultSuggestLimit    = 20
+
+	MediaRecommendationsQuery = `
+    query ($id: Int, $page: Int, $perPage: Int) {
+        Media (id: $id) {
+            id
+            recommendations (page: $page, perPage: $perPage, sort: [RATING_DESC, ID]) {
+                pageInfo {
+                    total
+                    perPage
+                    currentPage
+                    lastPage
+                    hasNextPage
+                }
+                nodes {
+                    id
+                    rating
+                    userRating
+                    mediaRecommendation {
+                        id
+                        type
+                        format
+                        title {
+                            romaji
+                            english
+                            native
+                        }
+                        coverImage {
+                            extraLarge
+                        }
+                        averageScore
+                    }
+                }
+            }
+        }
+    }
+    `
+
+	RecommendationsQuery = `
+    query ($page: Int, $perPage: Int) {
+      Page (page: $page, perPage: $perPage) {
+        pageInfo {
+          total
+          perPage
+          currentPage
+          lastPage
+          hasNextPage
+        }
+        recommendations (sort: [RATING_DESC, ID]) {
+          id
+          rating
+          media {
+            id
+            type
+            format
+            title {
+              romaji
+              english
+              native
+            }
+            coverImage {
+              extraLarge
+            }
+            averageScore
+          }
+          mediaRecommendation {
+            id
+            type
+            format
+            title {
+              romaji
+              english
+              native
+            }
+            coverImage {
+              extraLarge
+            }
+            averageScore
+          }
+        }
+      }
+    }
+    `
+)
+
+// Recommendation is a user submitted recommendation of MediaRecommendation
+// for people who liked Media. Rating is the sum of the up and down votes.
+// UserRating is the authenticated user's vote and empty otherwise.
+type Recommendation struct {
+	ID                  int    `json:"id"`
+	Rating              int    `json:"rating"`
+	UserRating          string `json:"userRating"`
+	Media               *Media `json:"media,omitempty"`
+	MediaRecommendation *Media `json:"mediaRecommendation,omitempty"`
+}
+
+type RecommendationConnection struct {
+	PageInfo PageInfo         `json:"pageInfo"`
+	Nodes    []Recommendation `json:"nodes"`
+}
+
+// SuggestOptions controls SuggestForUser. MinScore uses the POINT_100 format
+// of Update.Score. Zero values use the DefaultSuggest constants.
+type SuggestOptions struct {
+	MinScore int
+	PerTitle int
+	Limit    int
+}
+
+// Suggestion is a media suggested by SuggestForUser. Score is the sum of the
+// recommendation ratings weighted by the user's score of the title they were
+// recommended for, and BecauseOf holds those titles.
+type Suggestion struct {
+	Media     Media
+	Score     float64
+	BecauseOf []Update
+}
+
+// GetMediaRecommendations retrieves one page of the recommendations for a
+// media, highest rated first.
+//
+// Parameters:
+//   - mediaID: The AniList ID of the media.
+//   - page: The page to fetch, starting at 1.
+//   - perPage: The number of recommendations per page, at most 50.
+//
+// Returns:
+//   - A slice of Recommendation structs, where MediaRecommendation is the
+//     recommended media.
+//   - The PageInfo of the fetched page, to know whether there is a next page.
+//   - An error if there's any issue fetching the data.
+func GetMediaRecommendations(mediaID int, page int, perPage int) ([]Recommendation, PageInfo, error) {
+	variables := map[string]interface{}{
+		"id":      mediaID,
+		"page":    page,
+		"perPage": perPage,
+	}
+
+	media, err := fetchAnilistData(MediaRecommendationsQuery, variables)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	if media.Recommendations == nil {
+		return nil, PageInfo{}, nil
+	}
+	return media.Recommendations.Nodes, media.Recommendations.PageInfo, nil
+}
+
+// GetRecommendations retrieves one page of the recommendations of all media
+// on AniList, highest rated first.
+func GetRecommendations(page int, perPage int) ([]Recommendation, PageInfo, error) {
+	variables := map[string]interface{}{
+		"page":    page,
+		"perPage": perPage,
+	}
+
+	data, err := sendRequest(BaseAPIURL, RecommendationsQuery, variables, "")
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	if data.Data.Page == nil {
+		return nil, PageInfo{}, nil
+	}
+	return data.Data.Page.Recommendations, data.Data.Page.PageInfo, nil
+}
+
+// SuggestForUser suggests new media to a user, based on the recommendations
+// for the titles they completed and scored at least MinScore. Anything that
+// is already on the user's list is left out.
+//
+// Parameters:
+//   - updates: The user's updates, as returned by GetUpdates.
+//   - options: The SuggestOptions to apply.
+//
+// Returns:
+//   - Up to Limit suggestions, best first.
+//   - An error if there's any issue fetching the recommendations.
+//
+// Usage:
+//
+//	updates, err := GetUpdates("Ithilias", MediaTypeAnime, nil, nil)
+//	suggestions, err := SuggestForUser(updates, SuggestOptions{})
+//	for _, suggestion := range suggestions {
+//	    fmt.Printf("%s (%.1f)\n", suggestion.Media.Title.Romaji, suggestion.Score)
+//	}
+func SuggestForUser(updates []Update, options SuggestOptions) ([]Suggestion, error) {
+	options = options.withDefaults()
+
+	recommendations := make(map[int][]Recommendation)
+	for _, seed := range suggestionSeeds(updates, options.MinScore) {
+		seedRecommendations, _, err := GetMediaRecommendations(seed.MediaID, 1, options.PerTitle)
+		if err != nil {
+			return nil, fmt.Errorf("fetching recommendations for media %d: %w", seed.MediaID, err)
+		}
+		recommendations[seed.MediaID] = seedRecommendations
+	}
+
+	return rankSuggestions(updates, recommendations, options), nil
+}
+
+func (o SuggestOptions) withDefaults() SuggestOptions {
+	if o.MinScore <= 0 {
+		o.MinScore = DefaultSuggestMinScore
+	}
+	if o.PerTitle <= 0 {
+		o.PerTitle = DefaultSuggestPerTitle
+	}
+	if o.Limit <= 0 {
+		o.Limit = DefaultSuggestLimit
+	}
+	return o
+}
+
+// suggestionSeeds returns the completed updates scored at least minScore,
+// once per media, since GetUpdates returns an entry once per custom list it
+// is on.
+func suggestionSeeds(updates []Update, minScore int) []Update {
+	var seeds []Update
+	seen := make(map[int]bool)
+	for _, update := range updates {
+		if update.Status == "COMPLETED" && update.Score >= minScore && !seen[update.MediaID] {
+			seen[update.MediaID] = true
+			seeds = append(seeds, update)
+		}
+	}
+	return seeds
+}
+
+func rankSuggestions(updates []Update, recommendations map[int][]Recommendation, options SuggestOptions) []Suggestion {
+	onList := make(map[int]bool, len(updates))
+	for _, update := range updates {
+		onList[update.MediaID] = true
+	}
+
+	byID := make(map[int]*Suggestion)
+	for _, seed := range suggestionSeeds(updates, options.MinScore) {
+		for _, recommendation := range recommendations[seed.MediaID] {
+			media := recommendation.MediaRecommendation
+			if media == nil || onList[media.ID] || recommendation.Rating <= 0 {
+				continue
+			}
+
+			suggestion, ok := byID[media.ID]
+			if !ok {
+				suggestion = &Suggestion{Media: *media}
+				byID[media.ID] = suggestion
+			}
+			suggestion.Score += float64(recommendation.Rating) * float64(seed.Score) / 100
+			suggestion.BecauseOf = append(suggestion.BecauseOf, seed)
+		}
+	}
+
+	suggestions := make([]Suggestion, 0, len(byID))
+	for _, suggestion := range byID {
+		suggestions = append(suggestions, *suggestion)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Media.ID < suggestions[j].Media.ID
+	})
+
+	if len(suggestions) > options.Limit {
+		suggestions = suggestions[:options.Limit]
+	}
+	return suggestions
+}
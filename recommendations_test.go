@@ -0,0 +1,62 @@
+package anilistgo
+
+import (
+	"testing"
+)
+
+func TestRankSuggestions(t *testing.T) {
+	updates := []Update{
+		{MediaID: 1, Status: "COMPLETED", Score: 100},
+		{MediaID: 2, Status: "COMPLETED", Score: 80},
+		{MediaID: 3, Status: "COMPLETED", Score: 50},
+		{MediaID: 4, Status: "PLANNING"},
+	}
+	recommendations := map[int][]Recommendation{
+		1: {
+			{Rating: 10, MediaRecommendation: &Media{ID: 10}},
+			{Rating: 50, MediaRecommendation: &Media{ID: 4}},
+			{Rating: 5, MediaRecommendation: &Media{ID: 11}},
+		},
+		2: {
+			{Rating: 10, MediaRecommendation: &Media{ID: 11}},
+			{Rating: -3, MediaRecommendation: &Media{ID: 12}},
+		},
+		3: {
+			{Rating: 100, MediaRecommendation: &Media{ID: 13}},
+		},
+	}
+
+	suggestions := rankSuggestions(updates, recommendations, SuggestOptions{}.withDefaults())
+	if len(suggestions) != 2 {
+		t.Fatalf("expected 2 suggestions but got %v", suggestions)
+	}
+	if suggestions[0].Media.ID != 11 || suggestions[0].Score != 13 || len(suggestions[0].BecauseOf) != 2 {
+		t.Errorf("expected media 11 recommended for two titles first but got %+v", suggestions[0])
+	}
+	if suggestions[1].Media.ID != 10 || suggestions[1].Score != 10 {
+		t.Errorf("expected media 10 second but got %+v", suggestions[1])
+	}
+
+	limited := rankSuggestions(updates, recommendations, SuggestOptions{Limit: 1}.withDefaults())
+	if len(limited) != 1 {
+		t.Errorf("expected suggestions to be limited but got %v", limited)
+	}
+}
+
+func TestSuggestionSeedsAreUnique(t *testing.T) {
+	seed := Update{MediaID: 1, Status: "COMPLETED", Score: 90}
+	updates := []Update{seed, {MediaID: 2, Status: "COMPLETED", Score: 80}, seed}
+
+	seeds := suggestionSeeds(updates, DefaultSuggestMinScore)
+	if len(seeds) != 2 || seeds[0].MediaID != 1 || seeds[1].MediaID != 2 {
+		t.Errorf("expected each media to be a seed once but got %+v", seeds)
+	}
+
+	recommendations := map[int][]Recommendation{
+		1: {{Rating: 10, MediaRecommendation: &Media{ID: 10}}},
+	}
+	suggestions := rankSuggestions(updates, recommendations, SuggestOptions{}.withDefaults())
+	if len(suggestions) != 1 || suggestions[0].Score != 9 || len(suggestions[0].BecauseOf) != 1 {
+		t.Errorf("expected a seed on two lists to count once but got %+v", suggestions)
+	}
+}
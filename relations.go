@@ -0,0 +1,322 @@
+package anilistgo
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const (
+	RelationAdaptation  = "ADAPTATION"
+	RelationPrequel     = "PREQUEL"
+	RelationSequel      = "SEQUEL"
+	RelationParent      = "PARENT"
+	RelationSideStory   = "SIDE_STORY"
+	RelationCharacter   = "CHARACTER"
+	RelationSummary     = "SUMMARY"
+	RelationAlternative = "ALTERNATIVE"
+	RelationSpinOff     = "SPIN_OFF"
+	RelationOther       = "OTHER"
+	RelationSource      = "SOURCE"
+	RelationCompilation = "COMPILATION"
+	RelationContains    = "CONTAINS"
+
+	DefaultFranchiseConcurrency = 4
+	DefaultFranchiseMaxNodes    = 200
+
+	MediaRelationsQuery = `
+    query ($id: Int) {
+        Media (id: $id) {
+            id
+            type
+            format
+            title {
+                romaji
+                english
+                native
+            }
+            startDate {
+                year
+                month
+                day
+            }
+            episodes
+            chapters
+            relations {
+                edges {
+                    relationType (version: 2)
+                    node {
+                        id
+                        type
+                        format
+                        title {
+                            romaji
+                            english
+                            native
+                        }
+                        startDate {
+                            year
+                            month
+                            day
+                        }
+                        episodes
+                        chapters
+                    }
+                }
+            }
+        }
+    }
+    `
+)
+
+// FranchiseRelationTypes are the relation types followed by GetFranchise by
+// default. CHARACTER and OTHER are left out, as they mostly link unrelated
+// franchises that share a character or a setting.
+var FranchiseRelationTypes = []string{
+	RelationPrequel,
+	RelationSequel,
+	RelationParent,
+	RelationSideStory,
+	RelationSummary,
+	RelationAlternative,
+	RelationSpinOff,
+	RelationCompilation,
+	RelationContains,
+	RelationSource,
+	RelationAdaptation,
+}
+
+type MediaConnection struct {
+	PageInfo PageInfo    `json:"pageInfo"`
+	Edges    []MediaEdge `json:"edges"`
+}
+
+// MediaEdge links a media to a related media, where RelationType is one of
+// the Relation constants, describing what Node is to the media.
+type MediaEdge struct {
+	RelationType string `json:"relationType"`
+	Node         Media  `json:"node"`
+}
+
+// FranchiseOptions controls how GetFranchise crawls the relation graph. Zero
+// values use DefaultFranchiseConcurrency, FranchiseRelationTypes and
+// DefaultFranchiseMaxNodes.
+type FranchiseOptions struct {
+	Concurrency   int
+	RelationTypes []string
+	MaxNodes      int
+}
+
+// Franchise is the relation graph around a media. Media holds every crawled
+// media by ID, including its Relations. WatchOrder is the main line of the
+// franchise, following prequels and sequels of the same media type as the
+// root.
+type Franchise struct {
+	RootID     int
+	Media      map[int]Media
+	WatchOrder []Media
+}
+
+// GetMediaRelations retrieves a media along with all its related media.
+//
+// Usage:
+//
+//	media, err := GetMediaRelations(16498)
+//	for _, edge := range media.Relations.Edges {
+//	    if edge.RelationType == RelationSequel {
+//	        fmt.Println("Sequel:", edge.Node.Title.Romaji)
+//	    }
+//	}
+func GetMediaRelations(id int) (Media, error) {
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	media, err := fetchAnilistData(MediaRelationsQuery, variables)
+	if err != nil {
+		return Media{}, err
+	}
+	if media.ID == 0 {
+		return Media{}, fmt.Errorf("media %d not found", id)
+	}
+	return media, nil
+}
+
+// GetFranchise crawls the relation graph of a franchise starting from one
+// media, fetching up to Concurrency media at the same time. Every media is
+// only fetched once, so cycles in the graph are harmless, and crawling stops
+// once MaxNodes media have been fetched.
+//
+// Parameters:
+//   - id: The AniList ID of any media of the franchise.
+//   - options: The FranchiseOptions to apply.
+//
+// Returns:
+//   - The Franchise, including the watch order of its main line.
+//   - An error if any media could not be fetched.
+//
+// Usage:
+//
+//	franchise, err := GetFranchise(16498, FranchiseOptions{})
+//	for i, media := range franchise.WatchOrder {
+//	    fmt.Printf("%d. %s\n", i+1, media.Title.Romaji)
+//	}
+func GetFranchise(id int, options FranchiseOptions) (*Franchise, error) {
+	return crawlFranchise(id, options, GetMediaRelations)
+}
+
+func crawlFranchise(id int, options FranchiseOptions, fetch func(id int) (Media, error)) (*Franchise, error) {
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultFranchiseConcurrency
+	}
+	maxNodes := options.MaxNodes
+	if maxNodes <= 0 {
+		maxNodes = DefaultFranchiseMaxNodes
+	}
+	relationTypes := options.RelationTypes
+	if len(relationTypes) == 0 {
+		relationTypes = FranchiseRelationTypes
+	}
+	follow := make(map[string]bool, len(relationTypes))
+	for _, relationType := range relationTypes {
+		follow[relationType] = true
+	}
+
+	franchise := &Franchise{RootID: id, Media: make(map[int]Media)}
+	visited := map[int]bool{id: true}
+	frontier := []int{id}
+
+	for len(frontier) > 0 {
+		if remaining := maxNodes - len(franchise.Media); len(frontier) > remaining {
+			frontier = frontier[:remaining]
+		}
+
+		fetched, err := fetchConcurrently(frontier, concurrency, fetch)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []int
+		for _, media := range fetched {
+			franchise.Media[media.ID] = media
+			if media.Relations == nil {
+				continue
+			}
+			for _, edge := range media.Relations.Edges {
+				if !follow[edge.RelationType] || visited[edge.Node.ID] {
+					continue
+				}
+				visited[edge.Node.ID] = true
+				next = append(next, edge.Node.ID)
+			}
+		}
+		frontier = next
+	}
+
+	franchise.WatchOrder = watchOrder(id, franchise.Media)
+	return franchise, nil
+}
+
+func fetchConcurrently(ids []int, concurrency int, fetch func(id int) (Media, error)) ([]Media, error) {
+	results := make([]Media, len(ids))
+	errs := make([]error, len(ids))
+	semaphore := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, id int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			results[i], errs[i] = fetch(id)
+		}(i, id)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// watchOrder walks back from the root through prequels to the first entry of
+// the main line, then forward through sequels. Only media of the root's type
+// are considered, and when there are several candidates the one that started
+// first wins.
+func watchOrder(rootID int, media map[int]Media) []Media {
+	root, ok := media[rootID]
+	if !ok {
+		return nil
+	}
+
+	next := func(current Media, relationType string, seen map[int]bool) (Media, bool) {
+		if current.Relations == nil {
+			return Media{}, false
+		}
+
+		var candidates []Media
+		for _, edge := range current.Relations.Edges {
+			if edge.RelationType != relationType || seen[edge.Node.ID] {
+				continue
+			}
+			candidate, ok := media[edge.Node.ID]
+			if !ok || candidate.Type != root.Type {
+				continue
+			}
+			candidates = append(candidates, candidate)
+		}
+		if len(candidates) == 0 {
+			return Media{}, false
+		}
+
+		sort.Slice(candidates, func(i, j int) bool {
+			return fuzzyDateLess(candidates[i].StartDate, candidates[j].StartDate, candidates[i].ID, candidates[j].ID)
+		})
+		return candidates[0], true
+	}
+
+	first := root
+	seen := map[int]bool{root.ID: true}
+	for {
+		prequel, ok := next(first, RelationPrequel, seen)
+		if !ok {
+			break
+		}
+		seen[prequel.ID] = true
+		first = prequel
+	}
+
+	order := []Media{first}
+	seen = map[int]bool{first.ID: true}
+	for current := first; ; {
+		sequel, ok := next(current, RelationSequel, seen)
+		if !ok {
+			break
+		}
+		seen[sequel.ID] = true
+		order = append(order, sequel)
+		current = sequel
+	}
+
+	return order
+}
+
+// fuzzyDateLess orders dates chronologically, with unknown dates last and the
+// IDs as a tie breaker.
+func fuzzyDateLess(a FuzzyDate, b FuzzyDate, aID int, bID int) bool {
+	key := func(date FuzzyDate) int {
+		if date.Year == nil {
+			return 1 << 30
+		}
+		return *date.Year*10000 + intValue(date.Month)*100 + intValue(date.Day)
+	}
+
+	if key(a) != key(b) {
+		return key(a) < key(b)
+	}
+	return aID < bID
+}
@@ -0,0 +1,63 @@
+package anilistgo
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestCrawlFranchise(t *testing.T) {
+	year := func(y int) FuzzyDate { return FuzzyDate{Year: &y} }
+	edge := func(relationType string, id int) MediaEdge {
+		return MediaEdge{RelationType: relationType, Node: Media{ID: id}}
+	}
+	graph := map[int]Media{
+		1:  {ID: 1, Type: MediaTypeAnime, StartDate: year(2013), Relations: &MediaConnection{Edges: []MediaEdge{edge(RelationSequel, 2), edge(RelationAdaptation, 10)}}},
+		2:  {ID: 2, Type: MediaTypeAnime, StartDate: year(2017), Relations: &MediaConnection{Edges: []MediaEdge{edge(RelationPrequel, 1), edge(RelationSequel, 3), edge(RelationSideStory, 4), edge(RelationCharacter, 99)}}},
+		3:  {ID: 3, Type: MediaTypeAnime, StartDate: year(2018), Relations: &MediaConnection{Edges: []MediaEdge{edge(RelationPrequel, 2)}}},
+		4:  {ID: 4, Type: MediaTypeAnime, StartDate: year(2017), Relations: &MediaConnection{Edges: []MediaEdge{edge(RelationParent, 2)}}},
+		10: {ID: 10, Type: MediaTypeManga, StartDate: year(2009), Relations: &MediaConnection{Edges: []MediaEdge{edge(RelationAdaptation, 1), edge(RelationSequel, 11)}}},
+	}
+
+	var mu sync.Mutex
+	fetched := make(map[int]int)
+	fetch := func(id int) (Media, error) {
+		mu.Lock()
+		fetched[id]++
+		mu.Unlock()
+
+		media, ok := graph[id]
+		if !ok {
+			return Media{}, fmt.Errorf("media %d not found", id)
+		}
+		return media, nil
+	}
+
+	_, err := crawlFranchise(2, FranchiseOptions{Concurrency: 2}, fetch)
+	if err == nil {
+		t.Fatalf("expected an error for the unknown sequel of the manga but got none")
+	}
+
+	fetched = make(map[int]int)
+	franchise, err := crawlFranchise(2, FranchiseOptions{Concurrency: 2, RelationTypes: []string{RelationPrequel, RelationSequel, RelationSideStory, RelationParent, RelationAdaptation}, MaxNodes: 5}, fetch)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(franchise.Media) != 5 {
+		t.Errorf("expected 5 crawled media but got %d", len(franchise.Media))
+	}
+	for id, count := range fetched {
+		if count != 1 {
+			t.Errorf("expected media %d to be fetched once but was fetched %d times", id, count)
+		}
+	}
+
+	var order []int
+	for _, media := range franchise.WatchOrder {
+		order = append(order, media.ID)
+	}
+	if fmt.Sprint(order) != "[1 2 3]" {
+		t.Errorf("expected watch order [1 2 3] but got %v", order)
+	}
+}
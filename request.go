@@ -0,0 +1,366 @@
+package anilistgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultRequestsPerMinute = 90
+	MaxRetries               = 3
+)
+
+// retryBackoff is the delay before the first retry of a failed request that
+// did not send a Retry-After header. It doubles with every retry.
+var retryBackoff = time.Second
+
+var limiter = newRateLimiter(DefaultRequestsPerMinute)
+
+// GraphQLError is an error reported by the AniList API in the errors field of
+// a response. Path holds the response keys leading to the failed field, if
+// the error concerns a single field.
+type GraphQLError struct {
+	Message   string `json:"message"`
+	Status    int    `json:"status"`
+	Locations []struct {
+		Line   int `json:"line"`
+		Column int `json:"column"`
+	} `json:"locations,omitempty"`
+	Path []interface{} `json:"path,omitempty"`
+}
+
+// APIError is returned when AniList answers with an error status code, or
+// with GraphQL errors for requests made with Query. RetryAfter is set when
+// the request was rate limited.
+type APIError struct {
+	StatusCode         int
+	Errors             []GraphQLError
+	RateLimitLimit     string
+	RateLimitRemaining string
+	RetryAfter         time.Duration
+	Body               string
+}
+
+func (e *APIError) Error() string {
+	var messages []string
+	for _, graphQLError := range e.Errors {
+		messages = append(messages, graphQLError.Message)
+	}
+	message := strings.Join(messages, "; ")
+	if message == "" {
+		message = e.Body
+	}
+
+	return fmt.Sprintf(
+		"request failed with status code %d: %s (X-RateLimit-Limit: %s, X-RateLimit-Remaining: %s, Retry-After: %s)",
+		e.StatusCode,
+		message,
+		e.RateLimitLimit,
+		e.RateLimitRemaining,
+		e.RetryAfter,
+	)
+}
+
+// SetRateLimit sets the number of requests per minute that may be sent to
+// AniList, shared by all calls of the package. Requests above the limit wait
+// until they are allowed. A limit of zero or less disables rate limiting.
+func SetRateLimit(requestsPerMinute int) {
+	limiter.setLimit(requestsPerMinute)
+}
+
+// Query sends any GraphQL query or mutation to AniList and decodes the data
+// of the response into out. It is meant for fields the package does not
+// model, and shares the rate limiting and retries of the other calls.
+//
+// Parameters:
+//   - ctx: The context of the request, which cancels waiting for the rate
+//     limit and retries.
+//   - query: The GraphQL document.
+//   - vars: The variables of the query, such as a map or a struct, encoded as
+//     JSON. It can be nil.
+//   - out: A pointer to the value to decode the data field into. It can be
+//     nil to ignore the data.
+//
+// Returns:
+//   - An *APIError if AniList answered with an error status code or reported
+//     GraphQL errors. Partial data is still decoded into out in the latter
+//     case.
+//
+// Usage:
+//
+//	var out struct {
+//	    Media struct {
+//	        ID              int    `json:"id"`
+//	        CountryOfOrigin string `json:"countryOfOrigin"`
+//	    } `json:"Media"`
+//	}
+//	err := Query(ctx, `query ($id: Int) { Media (id: $id) { id countryOfOrigin } }`, map[string]int{"id": 21}, &out)
+func Query(ctx context.Context, query string, vars any, out any) error {
+	return runQuery(ctx, BaseAPIURL, query, vars, out, "")
+}
+
+// Query sends any GraphQL query or mutation to AniList like the package level
+// Query, but as the authenticated user.
+func (api *AuthenticatedAPI) Query(ctx context.Context, query string, vars any, out any) error {
+	return runQuery(ctx, BaseAPIURL, query, vars, out, api.AccessToken)
+}
+
+func runQuery(ctx context.Context, url string, query string, vars any, out any, accessToken string) error {
+	body, statusCode, err := doRequest(ctx, url, query, vars, accessToken)
+	if err != nil {
+		return err
+	}
+
+	var envelope struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []GraphQLError  `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return err
+	}
+
+	if out != nil && len(envelope.Data) > 0 && string(envelope.Data) != "null" {
+		if err := json.Unmarshal(envelope.Data, out); err != nil {
+			return err
+		}
+	}
+	if len(envelope.Errors) > 0 {
+		return &APIError{StatusCode: statusCode, Errors: envelope.Errors, Body: string(body)}
+	}
+	return nil
+}
+
+// doRequest sends a GraphQL request, waiting for the rate limiter and
+// retrying rate limited requests and server errors. It returns the body of
+// successful responses, and of 404 responses, which AniList sends when the
+// requested entity does not exist. Responses are served from and stored in
+// the cache set with SetCache, if any.
+func doRequest(ctx context.Context, url string, query string, variables any, accessToken string) ([]byte, int, error) {
+	key, ttl, cached, ok := cachedResponse(query, variables, accessToken)
+	if ok {
+		return cached, http.StatusOK, nil
+	}
+
+	reqBody, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	client := &http.Client{
+		Timeout: time.Second * Timeout,
+	}
+	mutation := operationKind(query) == "mutation"
+
+	for attempt := 0; ; attempt++ {
+		if err := limiter.wait(ctx); err != nil {
+			return nil, 0, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqBody))
+		if err != nil {
+			return nil, 0, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if accessToken != "" {
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, 0, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if (resp.StatusCode >= http.StatusOK && resp.StatusCode <= http.StatusIMUsed) || resp.StatusCode == http.StatusNotFound {
+			if key != "" && resp.StatusCode == http.StatusOK {
+				storeResponse(key, ttl, query, variables, body)
+			}
+			return body, resp.StatusCode, nil
+		}
+
+		apiErr := newAPIError(resp, body)
+		if !isRetryable(resp.StatusCode, mutation) || attempt >= MaxRetries {
+			return nil, resp.StatusCode, apiErr
+		}
+
+		delay := apiErr.RetryAfter
+		if resp.Header.Get("Retry-After") == "" {
+			delay = retryBackoff << attempt
+		}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			limiter.pause(delay)
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, resp.StatusCode, ctx.Err()
+		}
+	}
+}
+
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode:         resp.StatusCode,
+		RateLimitLimit:     resp.Header.Get("X-RateLimit-Limit"),
+		RateLimitRemaining: resp.Header.Get("X-RateLimit-Remaining"),
+		RetryAfter:         parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		Body:               string(body),
+	}
+
+	var envelope struct {
+		Errors []GraphQLError `json:"errors"`
+	}
+	if json.Unmarshal(body, &envelope) == nil {
+		apiErr.Errors = envelope.Errors
+	}
+	return apiErr
+}
+
+// isRetryable reports whether a failed request can be sent again. Mutations
+// are only retried when rate limited, since a server error does not tell
+// whether the change was applied.
+func isRetryable(statusCode int, mutation bool) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return !mutation
+	}
+	return false
+}
+
+// operationKind returns the kind of operation of a GraphQL document, such as
+// "query" or "mutation", where the shorthand "{ ... }" is a query.
+func operationKind(document string) string {
+	document = strings.TrimSpace(document)
+	if strings.HasPrefix(document, "{") {
+		return "query"
+	}
+	end := strings.IndexAny(document, " \t\r\n({")
+	if end < 0 {
+		return ""
+	}
+	return document[:end]
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// rateLimitBurst is the largest number of requests a rateLimiter sends at
+// once.
+const rateLimitBurst = 5
+
+// rateLimiter is a token bucket holding a tenth of the requests per minute, at
+// most rateLimitBurst, so short bursts are sent immediately. It refills at the
+// limit minus its capacity, so that a burst followed by a minute of refills
+// stays within the limit.
+type rateLimiter struct {
+	mu          sync.Mutex
+	perMinute   int
+	capacity    float64
+	perSecond   float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+func newRateLimiter(perMinute int) *rateLimiter {
+	l := &rateLimiter{}
+	l.setLimit(perMinute)
+	return l
+}
+
+func (l *rateLimiter) setLimit(perMinute int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	capacity := max(1, min(rateLimitBurst, perMinute/10))
+	refill := perMinute - capacity
+	if refill <= 0 {
+		refill = perMinute
+	}
+
+	l.perMinute = perMinute
+	l.capacity = float64(capacity)
+	l.perSecond = float64(refill) / 60
+	l.tokens = l.capacity
+	l.last = time.Now()
+}
+
+func (l *rateLimiter) pause(delay time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := time.Now().Add(delay); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		delay := l.reserve(time.Now())
+		if delay <= 0 {
+			return nil
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve takes a token and returns zero if one is available, or the time to
+// wait before trying again otherwise.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+	if l.perMinute <= 0 {
+		return 0
+	}
+
+	l.tokens += now.Sub(l.last).Seconds() * l.perSecond
+	if l.tokens > l.capacity {
+		l.tokens = l.capacity
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration(math.Ceil((1 - l.tokens) / l.perSecond * float64(time.Second)))
+}
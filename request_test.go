@@ -0,0 +1,154 @@
+package anilistgo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestMain disables the shared rate limiter, which would otherwise make the
+// tests against local servers wait.
+func TestMain(m *testing.M) {
+	SetRateLimit(0)
+	os.Exit(m.Run())
+}
+
+func TestRunQueryRetriesRateLimitedRequests(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("expected the access token to be sent but got %q", r.Header.Get("Authorization"))
+		}
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"data": {"Media": {"id": 21, "countryOfOrigin": "JP"}}}`))
+	}))
+	defer server.Close()
+
+	var out struct {
+		Media struct {
+			ID              int    `json:"id"`
+			CountryOfOrigin string `json:"countryOfOrigin"`
+		} `json:"Media"`
+	}
+	err := runQuery(context.Background(), server.URL, "query { Media { id countryOfOrigin } }", nil, &out, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 2 || out.Media.ID != 21 || out.Media.CountryOfOrigin != "JP" {
+		t.Errorf("expected a decoded response after 2 attempts but got %+v after %d", out, attempts)
+	}
+}
+
+func TestRunQueryReturnsAPIErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"data": null, "errors": [{"message": "Unknown field", "status": 400}]}`))
+	}))
+	defer server.Close()
+
+	err := runQuery(context.Background(), server.URL, "query { Unknown }", nil, nil, "")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError but got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || len(apiErr.Errors) != 1 || apiErr.Errors[0].Message != "Unknown field" {
+		t.Errorf("unexpected APIError: %+v", apiErr)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Duration{
+		"":                              0,
+		"30":                            30 * time.Second,
+		"Mon, 01 Jan 2024 12:01:00 GMT": time.Minute,
+		"soon":                          0,
+	}
+
+	for value, expected := range tests {
+		if result := parseRetryAfter(value, now); result != expected {
+			t.Errorf("expected %q to be parsed as %v but got %v", value, expected, result)
+		}
+	}
+}
+
+func TestRateLimiterReserve(t *testing.T) {
+	l := newRateLimiter(65)
+	now := l.last
+
+	for i := 0; i < rateLimitBurst; i++ {
+		if l.reserve(now) != 0 {
+			t.Fatalf("expected request %d of the burst to be allowed immediately", i+1)
+		}
+	}
+	if delay := l.reserve(now); delay != time.Second {
+		t.Errorf("expected to wait one second for the next token but got %v", delay)
+	}
+	if l.reserve(now.Add(time.Second)) != 0 {
+		t.Error("expected a token to be available after one second")
+	}
+
+	l.pausedUntil = now.Add(5 * time.Second)
+	if delay := l.reserve(now.Add(time.Second)); delay != 4*time.Second {
+		t.Errorf("expected to wait for the pause to end but got %v", delay)
+	}
+}
+
+func TestRateLimiterStaysWithinLimit(t *testing.T) {
+	l := newRateLimiter(DefaultRequestsPerMinute)
+	start := l.last
+
+	sent := 0
+	for now := start; now.Before(start.Add(time.Minute)); now = now.Add(10 * time.Millisecond) {
+		for l.reserve(now) == 0 {
+			sent++
+		}
+	}
+	if sent > DefaultRequestsPerMinute {
+		t.Errorf("expected at most %d requests in the first minute but got %d", DefaultRequestsPerMinute, sent)
+	}
+}
+
+func TestDoRequestDoesNotRetryMutationsOnServerErrors(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, _, err := doRequest(context.Background(), server.URL, SaveTextActivityQuery, map[string]interface{}{"text": "hello"}, "token")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected a 502 APIError but got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected the mutation to be sent once but it was sent %d times", attempts)
+	}
+}
+
+func TestOperationKind(t *testing.T) {
+	tests := map[string]string{
+		"{ Media { id } }":                 "query",
+		"\n    query ($id: Int) { Media }": "query",
+		"mutation($id: Int) { Delete }":    "mutation",
+		"mutation{ Delete }":               "mutation",
+		UpdateProgressQuery:                "mutation",
+	}
+	for document, expected := range tests {
+		if kind := operationKind(document); kind != expected {
+			t.Errorf("expected %q for %q but got %q", expected, document, kind)
+		}
+	}
+}
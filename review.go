@@ -0,0 +1,323 @@
+package anilistgo
+
+import (
+	"fmt"
+)
+
+const (
+	ReviewUpVote   = "UP_VOTE"
+	ReviewDownVote = "DOWN_VOTE"
+	ReviewNoVote   = "NO_VOTE"
+
+	ReviewsQuery = `
+    query ($mediaId: Int, $userId: Int, $page: Int, $perPage: Int) {
+      Page (page: $page, perPage: $perPage) {
+        pageInfo {
+          total
+          perPage
+          currentPage
+          lastPage
+          hasNextPage
+        }
+        reviews (mediaId: $mediaId, userId: $userId, sort: [RATING_DESC, ID]) {
+          id
+          userId
+          mediaId
+          mediaType
+          summary
+          rating
+          ratingAmount
+          score
+          siteUrl
+          createdAt
+          updatedAt
+          user {
+            id
+            name
+          }
+          media {
+            id
+            type
+            title {
+              romaji
+              english
+              native
+            }
+          }
+        }
+      }
+    }
+    `
+
+	ReviewQuery = `
+    query ($id: Int, $asHtml: Boolean) {
+      Review (id: $id) {
+        id
+        userId
+        mediaId
+        mediaType
+        summary
+        body (asHtml: $asHtml)
+        rating
+        ratingAmount
+        userRating
+        score
+        private
+        siteUrl
+        createdAt
+        updatedAt
+        user {
+          id
+          name
+        }
+        media {
+          id
+          type
+          title {
+            romaji
+            english
+            native
+          }
+        }
+      }
+    }
+    `
+
+	SaveReviewQuery = `
+    mutation ($id: Int, $mediaId: Int, $body: String, $summary: String, $score: Int, $private: Boolean) {
+      SaveReview (id: $id, mediaId: $mediaId, body: $body, summary: $summary, score: $score, private: $private) {
+        id
+        userId
+        mediaId
+        mediaType
+        summary
+        body
+        score
+        private
+        siteUrl
+        createdAt
+        updatedAt
+      }
+    }
+    `
+
+	DeleteReviewQuery = `
+    mutation ($id: Int) {
+      DeleteReview (id: $id) {
+        deleted
+      }
+    }
+    `
+
+	RateReviewQuery = `
+    mutation ($reviewId: Int, $rating: ReviewRating) {
+      RateReview (reviewId: $reviewId, rating: $rating) {
+        id
+        rating
+        ratingAmount
+        userRating
+      }
+    }
+    `
+)
+
+// Review is a user's review of a media. Score is the reviewer's score out of
+// 100. Rating is the number of users who found the review helpful out of
+// RatingAmount, and UserRating is the authenticated user's vote, one of the
+// Review vote constants. Body is only set when fetching a single review.
+type Review struct {
+	ID           int       `json:"id"`
+	UserID       int       `json:"userId"`
+	MediaID      int       `json:"mediaId"`
+	MediaType    string    `json:"mediaType"`
+	Summary      string    `json:"summary"`
+	Body         string    `json:"body,omitempty" graphql:"-"`
+	Rating       int       `json:"rating"`
+	RatingAmount int       `json:"ratingAmount"`
+	UserRating   string    `json:"userRating,omitempty"`
+	Score        int       `json:"score"`
+	Private      bool      `json:"private"`
+	SiteURL      string    `json:"siteUrl"`
+	CreatedAt    int64     `json:"createdAt"`
+	UpdatedAt    int64     `json:"updatedAt"`
+	User         *UserInfo `json:"user,omitempty"`
+	Media        *Media    `json:"media,omitempty"`
+}
+
+type ReviewConnection struct {
+	PageInfo PageInfo `json:"pageInfo"`
+	Nodes    []Review `json:"nodes"`
+}
+
+// SaveReviewOp describes a review to create or update with SaveReview. ID is
+// the ID of the review to update, or zero to create a new review of the
+// media. AniList requires a summary of 20 to 120 characters and a body of at
+// least 2200 characters.
+type SaveReviewOp struct {
+	ID      int
+	MediaID int
+	Body    string
+	Summary string
+	Score   int
+	Private bool
+}
+
+// GetMediaReviews retrieves one page of the reviews of a media, most helpful
+// first. The reviews are returned without their body, which can be fetched
+// with GetReview.
+//
+// Parameters:
+//   - mediaID: The AniList ID of the media.
+//   - page: The page to fetch, starting at 1.
+//   - perPage: The number of reviews per page, at most 50.
+//
+// Returns:
+//   - A slice of Review structs.
+//   - The PageInfo of the fetched page, to know whether there is a next page.
+//   - An error if there's any issue fetching the data.
+//
+// Usage:
+//
+//	reviews, _, err := GetMediaReviews(21, 1, 5)
+//	for _, review := range reviews {
+//	    fmt.Printf("%d/100: %s\n", review.Score, review.Summary)
+//	}
+func GetMediaReviews(mediaID int, page int, perPage int) ([]Review, PageInfo, error) {
+	variables := map[string]interface{}{
+		"mediaId": mediaID,
+	}
+	return fetchReviews(variables, page, perPage)
+}
+
+// GetUserReviews retrieves one page of the reviews written by a user, most
+// helpful first, without their body.
+func GetUserReviews(username string, page int, perPage int) ([]Review, PageInfo, error) {
+	userID, err := fetchUserID(UserQuery, map[string]interface{}{"name": username})
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	if userID == 0 {
+		return nil, PageInfo{}, fmt.Errorf("user %s not found", username)
+	}
+
+	variables := map[string]interface{}{
+		"userId": userID,
+	}
+	return fetchReviews(variables, page, perPage)
+}
+
+// GetReview retrieves a single review along with its body, as markdown or as
+// HTML if asHTML is true.
+func GetReview(id int, asHTML bool) (Review, error) {
+	variables := map[string]interface{}{
+		"id":     id,
+		"asHtml": asHTML,
+	}
+
+	data, err := sendRequest(BaseAPIURL, ReviewQuery, variables, "")
+	if err != nil {
+		return Review{}, err
+	}
+	if data.Data.Review == nil {
+		return Review{}, fmt.Errorf("review %d not found", id)
+	}
+	return *data.Data.Review, nil
+}
+
+// SaveReview creates or updates a review of the authenticated user, and
+// returns the saved review.
+//
+// Usage:
+//
+//	review, err := api.SaveReview(SaveReviewOp{MediaID: 21, Summary: summary, Body: body, Score: 90})
+func (api *AuthenticatedAPI) SaveReview(op SaveReviewOp) (Review, error) {
+	data, err := sendRequest(BaseAPIURL, SaveReviewQuery, op.variables(), api.AccessToken)
+	if err != nil {
+		return Review{}, err
+	}
+	if data.Data.SaveReview == nil {
+		return Review{}, fmt.Errorf("review of media %d was not saved", op.MediaID)
+	}
+	return *data.Data.SaveReview, nil
+}
+
+// DeleteReview deletes a review of the authenticated user.
+func (api *AuthenticatedAPI) DeleteReview(id int) error {
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	data, err := sendRequest(BaseAPIURL, DeleteReviewQuery, variables, api.AccessToken)
+	if err != nil {
+		return err
+	}
+	if data.Data.DeleteReview == nil || !data.Data.DeleteReview.Deleted {
+		return fmt.Errorf("review %d was not deleted", id)
+	}
+	return nil
+}
+
+// RateReview votes on whether a review was helpful, where rating is one of
+// ReviewUpVote, ReviewDownVote or ReviewNoVote to remove the vote. It returns
+// the review with its updated Rating, RatingAmount and UserRating.
+func (api *AuthenticatedAPI) RateReview(id int, rating string) (Review, error) {
+	if rating != ReviewUpVote && rating != ReviewDownVote && rating != ReviewNoVote {
+		return Review{}, fmt.Errorf("invalid review rating: %s", rating)
+	}
+
+	variables := map[string]interface{}{
+		"reviewId": id,
+		"rating":   rating,
+	}
+
+	data, err := sendRequest(BaseAPIURL, RateReviewQuery, variables, api.AccessToken)
+	if err != nil {
+		return Review{}, err
+	}
+	if data.Data.RateReview == nil {
+		return Review{}, fmt.Errorf("review %d was not rated", id)
+	}
+	return *data.Data.RateReview, nil
+}
+
+func (op SaveReviewOp) variables() map[string]interface{} {
+	variables := map[string]interface{}{
+		"mediaId": op.MediaID,
+		"body":    op.Body,
+		"summary": op.Summary,
+		"score":   op.Score,
+		"private": op.Private,
+	}
+	if op.ID != 0 {
+		variables["id"] = op.ID
+	}
+	return variables
+}
+
+func (c *ReviewConnection) count() int {
+	if c == nil {
+		return 0
+	}
+	return c.PageInfo.Total
+}
+
+func (c *ReviewConnection) nodes() []Review {
+	if c == nil {
+		return nil
+	}
+	return c.Nodes
+}
+
+func fetchReviews(variables map[string]interface{}, page int, perPage int) ([]Review, PageInfo, error) {
+	variables["page"] = page
+	variables["perPage"] = perPage
+
+	data, err := sendRequest(BaseAPIURL, ReviewsQuery, variables, "")
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	if data.Data.Page == nil {
+		return nil, PageInfo{}, nil
+	}
+	return data.Data.Page.Reviews, data.Data.Page.PageInfo, nil
+}
@@ -0,0 +1,37 @@
+package anilistgo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSaveReviewOpVariables(t *testing.T) {
+	op := SaveReviewOp{MediaID: 21, Summary: "summary", Body: "body", Score: 90}
+	expected := map[string]interface{}{
+		"mediaId": 21,
+		"body":    "body",
+		"summary": "summary",
+		"score":   90,
+		"private": false,
+	}
+	if variables := op.variables(); !reflect.DeepEqual(variables, expected) {
+		t.Errorf("expected %v but got %v", expected, variables)
+	}
+
+	op.ID = 7
+	if variables := op.variables(); variables["id"] != 7 {
+		t.Errorf("expected the review ID to be set when updating but got %v", variables)
+	}
+}
+
+func TestReviewConnectionWithoutReviews(t *testing.T) {
+	var connection *ReviewConnection
+	if connection.count() != 0 || connection.nodes() != nil {
+		t.Error("expected no reviews for a nil connection")
+	}
+
+	connection = &ReviewConnection{PageInfo: PageInfo{Total: 12}, Nodes: []Review{{ID: 1, Score: 80}}}
+	if connection.count() != 12 || len(connection.nodes()) != 1 {
+		t.Errorf("unexpected count %d or reviews %v", connection.count(), connection.nodes())
+	}
+}
@@ -0,0 +1,141 @@
+package anilistgo
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	timeType            = reflect.TypeOf(time.Time{})
+)
+
+// SelectionSet returns the GraphQL selection set that fetches every field of
+// the struct v decodes, such as "{ id title { romaji english native } }" for
+// a struct with an ID and a Title field. It makes queries follow the structs
+// decoding them, so adding a field to a struct adds it to the query.
+//
+// Fields are named after their json tag, or their name in lower camel case
+// if they have none. Pointers, slices and nested structs are followed, and
+// maps and time.Time are treated as scalars. A field whose type is already
+// being selected further up is left out, which breaks cycles such as
+// Media.NextAiringEpisode.Media.
+//
+// The graphql struct tag controls the selection of a field: "-" leaves the
+// field out, and anything else is added as arguments after the field name.
+// Types with their own UnmarshalJSON method, such as unions, decode data the
+// struct does not describe, so they are left out unless their tag holds
+// their selection set, with any arguments before it.
+//
+//	type Entry struct {
+//	    Score    int           `json:"score" graphql:"(format: POINT_100)"`
+//	    Notes    string        `json:"notes" graphql:"-"`
+//	    Activity ActivityUnion `json:"activity" graphql:"{ __typename ... on TextActivity { id } }"`
+//	}
+//
+// Usage:
+//
+//	query := "query ($id: Int) { Media (id: $id) " + SelectionSet(Media{}) + " }"
+func SelectionSet(v any) string {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return ""
+	}
+
+	var b strings.Builder
+	writeSelectionSet(&b, t, map[reflect.Type]bool{})
+	return b.String()
+}
+
+func writeSelectionSet(b *strings.Builder, t reflect.Type, path map[reflect.Type]bool) {
+	path[t] = true
+	defer delete(path, t)
+
+	b.WriteString("{")
+	writeFields(b, t, path)
+	b.WriteString(" }")
+}
+
+func writeFields(b *strings.Builder, t reflect.Type, path map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		arguments := field.Tag.Get("graphql")
+		if arguments == "-" {
+			continue
+		}
+
+		name, skip := selectionFieldName(field)
+		if skip {
+			continue
+		}
+
+		fieldType := baseType(field.Type)
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			writeFields(b, fieldType, path)
+			continue
+		}
+		if name == "" {
+			name = lowerCamelCase(field.Name)
+		}
+
+		if isUnmarshaler(fieldType) {
+			if strings.Contains(arguments, "{") {
+				if strings.HasPrefix(arguments, "{") {
+					arguments = " " + arguments
+				}
+				b.WriteString(" " + name + arguments)
+			}
+			continue
+		}
+		if isScalar(fieldType) {
+			b.WriteString(" " + name + arguments)
+			continue
+		}
+		if path[fieldType] {
+			continue
+		}
+
+		b.WriteString(" " + name + arguments + " ")
+		writeSelectionSet(b, fieldType, path)
+	}
+}
+
+func selectionFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name, false
+}
+
+func baseType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	return t
+}
+
+func isUnmarshaler(t reflect.Type) bool {
+	return t != timeType && (t.Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(jsonUnmarshalerType))
+}
+
+func isScalar(t reflect.Type) bool {
+	return t == timeType || t.Kind() != reflect.Struct
+}
+
+func lowerCamelCase(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[size:]
+}
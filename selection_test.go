@@ -0,0 +1,45 @@
+package anilistgo
+
+import (
+	"strings"
+	"testing"
+)
+
+type selectionNode struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name,omitempty"`
+	Internal string `json:"-"`
+	Notes    string `json:"notes" graphql:"-"`
+	Score    int    `json:"score" graphql:"(format: POINT_100)"`
+	Date     FuzzyDate
+	Children []*selectionNode `json:"children"`
+	Union    ActivityUnion    `json:"union"`
+	Activity ActivityUnion    `json:"activity" graphql:"(id: 1) { __typename ... on TextActivity { id } }"`
+	Reply    *ActivityUnion   `json:"reply" graphql:"{ __typename }"`
+}
+
+func TestSelectionSet(t *testing.T) {
+	expected := "{ id name score(format: POINT_100) date { year month day } activity(id: 1) { __typename ... on TextActivity { id } } reply { __typename } }"
+	if result := SelectionSet(&selectionNode{}); result != expected {
+		t.Errorf("expected %q but got %q", expected, result)
+	}
+
+	if result := SelectionSet(42); result != "" {
+		t.Errorf("expected no selection set for a scalar but got %q", result)
+	}
+}
+
+func TestMediaSelectionSet(t *testing.T) {
+	selection := SelectionSet(Media{})
+
+	for _, field := range []string{"coverImage { extraLarge }", "nextAiringEpisode { id airingAt timeUntilAiring episode mediaId }", "reviews(sort: [RATING_DESC, ID], perPage: 3)"} {
+		if !strings.Contains(selection, field) {
+			t.Errorf("expected %q in the media selection set %q", field, selection)
+		}
+	}
+	for _, field := range []string{"relations", "recommendations", "body"} {
+		if strings.Contains(selection, field) {
+			t.Errorf("expected %q to be left out of the media selection set %q", field, selection)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package anilistgo
+
+import (
+	"fmt"
+)
+
+const (
+	LikeableActivity      = "ACTIVITY"
+	LikeableActivityReply = "ACTIVITY_REPLY"
+	LikeableThread        = "THREAD"
+	LikeableThreadComment = "THREAD_COMMENT"
+
+	ToggleLikeQuery = `
+    mutation ($id: Int, $type: LikeableType) {
+      ToggleLikeV2 (id: $id, type: $type) {
+        __typename
+        ... on ListActivity {
+          isLiked
+          likeCount
+        }
+        ... on TextActivity {
+          isLiked
+          likeCount
+        }
+        ... on MessageActivity {
+          isLiked
+          likeCount
+        }
+        ... on ActivityReply {
+          isLiked
+          likeCount
+        }
+        ... on Thread {
+          isLiked
+          likeCount
+        }
+        ... on ThreadComment {
+          isLiked
+          likeCount
+        }
+      }
+    }
+    `
+
+	LikesQuery = `
+    query ($id: Int, $type: LikeableType, $page: Int, $perPage: Int) {
+      Page (page: $page, perPage: $perPage) {
+        pageInfo {
+          total
+          perPage
+          currentPage
+          lastPage
+          hasNextPage
+        }
+        users: likes (likeableId: $id, type: $type) {
+          id
+          name
+        }
+      }
+    }
+    `
+
+	SaveActivityReplyQuery = `
+    mutation ($id: Int, $activityId: Int, $text: String) {
+      SaveActivityReply (id: $id, activityId: $activityId, text: $text) {
+        id
+        userId
+        activityId
+        text
+        likeCount
+        isLiked
+        createdAt
+        user {
+          id
+          name
+        }
+      }
+    }
+    `
+
+	DeleteActivityReplyQuery = `
+    mutation ($id: Int) {
+      DeleteActivityReply (id: $id) {
+        deleted
+      }
+    }
+    `
+
+	ToggleActivitySubscriptionQuery = `
+    mutation ($activityId: Int, $subscribe: Boolean) {
+      ToggleActivitySubscription (activityId: $activityId, subscribe: $subscribe) {
+        __typename
+        ... on ListActivity {
+          id
+          isSubscribed
+        }
+        ... on TextActivity {
+          id
+          isSubscribed
+        }
+        ... on MessageActivity {
+          id
+          isSubscribed
+        }
+      }
+    }
+    `
+)
+
+// LikeStatus is the state of a likeable item after ToggleLike.
+type LikeStatus struct {
+	IsLiked   bool `json:"isLiked"`
+	LikeCount int  `json:"likeCount"`
+}
+
+// ActivityReply is a reply to an activity.
+type ActivityReply struct {
+	ID         int       `json:"id"`
+	UserID     int       `json:"userId"`
+	ActivityID int       `json:"activityId"`
+	Text       string    `json:"text"`
+	LikeCount  int       `json:"likeCount"`
+	IsLiked    bool      `json:"isLiked"`
+	CreatedAt  int64     `json:"createdAt"`
+	User       *UserInfo `json:"user,omitempty"`
+}
+
+// ToggleLike likes an activity, activity reply, thread or thread comment as
+// the authenticated user, or removes the like if it was already liked.
+//
+// Parameters:
+//   - likeableType: One of the Likeable constants, such as LikeableActivity.
+//   - id: The ID of the item to like.
+//
+// Returns:
+//   - The LikeStatus of the item after toggling.
+//   - An error if there's any issue sending the request.
+//
+// Usage:
+//
+//	status, err := api.ToggleLike(LikeableActivity, 123456)
+//	fmt.Printf("liked: %t, %d likes\n", status.IsLiked, status.LikeCount)
+func (api *AuthenticatedAPI) ToggleLike(likeableType string, id int) (LikeStatus, error) {
+	if !isValidLikeableType(likeableType) {
+		return LikeStatus{}, fmt.Errorf("invalid likeable type: %s", likeableType)
+	}
+
+	variables := map[string]interface{}{
+		"id":   id,
+		"type": likeableType,
+	}
+
+	data, err := sendRequest(BaseAPIURL, ToggleLikeQuery, variables, api.AccessToken)
+	if err != nil {
+		return LikeStatus{}, err
+	}
+	if data.Data.ToggleLikeV2 == nil {
+		return LikeStatus{}, fmt.Errorf("like of %s %d was not toggled", likeableType, id)
+	}
+	return *data.Data.ToggleLikeV2, nil
+}
+
+// GetLikes retrieves one page of the users who liked an activity, activity
+// reply, thread or thread comment.
+//
+// Usage:
+//
+//	users, err := CollectPages(func(page int) ([]UserInfo, PageInfo, error) {
+//	    return GetLikes(LikeableActivity, 123456, page, MaxPerPage)
+//	})
+func GetLikes(likeableType string, id int, page int, perPage int) ([]UserInfo, PageInfo, error) {
+	if !isValidLikeableType(likeableType) {
+		return nil, PageInfo{}, fmt.Errorf("invalid likeable type: %s", likeableType)
+	}
+
+	variables := map[string]interface{}{
+		"id":      id,
+		"type":    likeableType,
+		"page":    page,
+		"perPage": perPage,
+	}
+
+	data, err := sendRequest(BaseAPIURL, LikesQuery, variables, "")
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	if data.Data.Page == nil {
+		return nil, PageInfo{}, nil
+	}
+	return data.Data.Page.Users, data.Data.Page.PageInfo, nil
+}
+
+// GetActivityLikes retrieves one page of the users who liked an activity.
+func GetActivityLikes(activityID int, page int, perPage int) ([]UserInfo, PageInfo, error) {
+	return GetLikes(LikeableActivity, activityID, page, perPage)
+}
+
+// SaveActivityReply replies to an activity as the authenticated user, and
+// returns the created reply.
+//
+// Usage:
+//
+//	reply, err := api.SaveActivityReply(123456, "Congratulations on 1000 episodes!")
+func (api *AuthenticatedAPI) SaveActivityReply(activityID int, text string) (ActivityReply, error) {
+	variables := map[string]interface{}{
+		"activityId": activityID,
+		"text":       text,
+	}
+	return api.saveActivityReply(variables)
+}
+
+// EditActivityReply replaces the text of a reply of the authenticated user.
+func (api *AuthenticatedAPI) EditActivityReply(id int, text string) (ActivityReply, error) {
+	variables := map[string]interface{}{
+		"id":   id,
+		"text": text,
+	}
+	return api.saveActivityReply(variables)
+}
+
+// DeleteActivityReply deletes a reply of the authenticated user.
+func (api *AuthenticatedAPI) DeleteActivityReply(id int) error {
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	data, err := sendRequest(BaseAPIURL, DeleteActivityReplyQuery, variables, api.AccessToken)
+	if err != nil {
+		return err
+	}
+	if data.Data.DeleteActivityReply == nil || !data.Data.DeleteActivityReply.Deleted {
+		return fmt.Errorf("activity reply %d was not deleted", id)
+	}
+	return nil
+}
+
+// ToggleActivitySubscription subscribes the authenticated user to the replies
+// of an activity, or unsubscribes them if subscribe is false.
+func (api *AuthenticatedAPI) ToggleActivitySubscription(activityID int, subscribe bool) error {
+	variables := map[string]interface{}{
+		"activityId": activityID,
+		"subscribe":  subscribe,
+	}
+
+	data, err := sendRequest(BaseAPIURL, ToggleActivitySubscriptionQuery, variables, api.AccessToken)
+	if err != nil {
+		return err
+	}
+	if data.Data.ToggleActivitySubscription == nil {
+		return fmt.Errorf("subscription to activity %d was not updated", activityID)
+	}
+	subscribed, err := activityIsSubscribed(data.Data.ToggleActivitySubscription.Activity)
+	if err != nil {
+		return err
+	}
+	if subscribed != subscribe {
+		return fmt.Errorf("subscription to activity %d was not updated", activityID)
+	}
+	return nil
+}
+
+// activityIsSubscribed reports whether the authenticated user is subscribed
+// to an activity. It returns an error for activities of unknown types.
+func activityIsSubscribed(activity Activity) (bool, error) {
+	switch activity := activity.(type) {
+	case ListActivity:
+		return activity.IsSubscribed, nil
+	case TextActivity:
+		return activity.IsSubscribed, nil
+	case MessageActivity:
+		return activity.IsSubscribed, nil
+	}
+	if activity == nil {
+		return false, fmt.Errorf("cannot determine subscription state of a missing activity")
+	}
+	return false, fmt.Errorf("cannot determine subscription state of activity %d", activity.ActivityID())
+}
+
+func (api *AuthenticatedAPI) saveActivityReply(variables map[string]interface{}) (ActivityReply, error) {
+	data, err := sendRequest(BaseAPIURL, SaveActivityReplyQuery, variables, api.AccessToken)
+	if err != nil {
+		return ActivityReply{}, err
+	}
+	if data.Data.SaveActivityReply == nil {
+		return ActivityReply{}, fmt.Errorf("activity reply was not saved")
+	}
+	return *data.Data.SaveActivityReply, nil
+}
+
+func isValidLikeableType(likeableType string) bool {
+	switch likeableType {
+	case LikeableActivity, LikeableActivityReply, LikeableThread, LikeableThreadComment:
+		return true
+	}
+	return false
+}
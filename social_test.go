@@ -0,0 +1,60 @@
+package anilistgo
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDecodeToggleLike(t *testing.T) {
+	body := `{"data": {"ToggleLikeV2": {"__typename": "ActivityReply", "isLiked": true, "likeCount": 3}}}`
+
+	var response Response
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	status := response.Data.ToggleLikeV2
+	if status == nil || !status.IsLiked || status.LikeCount != 3 {
+		t.Errorf("expected a liked status with 3 likes but got %+v", status)
+	}
+}
+
+func TestToggleLikeRejectsInvalidType(t *testing.T) {
+	api := NewAuthenticatedAPI("token")
+	if _, err := api.ToggleLike("MEDIA", 1); err == nil {
+		t.Error("expected an error for an invalid likeable type")
+	}
+	if _, _, err := GetLikes("MEDIA", 1, 1, PerPage); err == nil {
+		t.Error("expected an error for an invalid likeable type")
+	}
+}
+
+func TestActivityIsSubscribed(t *testing.T) {
+	tests := map[string]bool{
+		`{"__typename": "TextActivity", "id": 1, "isSubscribed": true}`:    true,
+		`{"__typename": "ListActivity", "id": 1, "isSubscribed": false}`:   false,
+		`{"__typename": "MessageActivity", "id": 1, "isSubscribed": true}`: true,
+	}
+	for body, expected := range tests {
+		var union ActivityUnion
+		if err := json.Unmarshal([]byte(body), &union); err != nil {
+			t.Fatal(err)
+		}
+		result, err := activityIsSubscribed(union.Activity)
+		if err != nil {
+			t.Fatalf("expected no error but got %v for %s", err, body)
+		}
+		if result != expected {
+			t.Errorf("expected %v but got %v for %s", expected, result, body)
+		}
+	}
+
+	var union ActivityUnion
+	if err := json.Unmarshal([]byte(`{"__typename": "ReviewActivity", "id": 1, "isSubscribed": true}`), &union); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := activityIsSubscribed(union.Activity); err == nil || !strings.Contains(err.Error(), "cannot determine subscription state") {
+		t.Errorf("expected an error for an activity of an unknown type but got %v", err)
+	}
+}
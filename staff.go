@@ -0,0 +1,213 @@
+package anilistgo
+
+import (
+	"fmt"
+)
+
+const (
+	StaffQuery = `
+    query ($id: Int, $page: Int, $perPage: Int) {
+      Staff (id: $id) {
+        id
+        name {
+          first
+          middle
+          last
+          full
+          native
+          alternative
+        }
+        languageV2
+        image {
+          large
+          medium
+        }
+        description
+        primaryOccupations
+        gender
+        dateOfBirth {
+          year
+          month
+          day
+        }
+        yearsActive
+        homeTown
+        favourites
+        siteUrl
+        staffMedia (page: $page, perPage: $perPage, sort: POPULARITY_DESC) {
+          pageInfo {
+            total
+            perPage
+            currentPage
+            lastPage
+            hasNextPage
+          }
+          edges {
+            staffRole
+            node {
+              id
+              type
+              format
+              title {
+                romaji
+                english
+                native
+              }
+              coverImage {
+                extraLarge
+              }
+            }
+          }
+        }
+        characters (page: $page, perPage: $perPage, sort: FAVOURITES_DESC) {
+          pageInfo {
+            total
+            perPage
+            currentPage
+            lastPage
+            hasNextPage
+          }
+          edges {
+            role
+            node {
+              id
+              name {
+                full
+                native
+              }
+              image {
+                large
+                medium
+              }
+              siteUrl
+            }
+          }
+        }
+      }
+    }
+    `
+
+	StaffSearchQuery = `
+    query ($search: String, $page: Int, $perPage: Int) {
+      Page (page: $page, perPage: $perPage) {
+        pageInfo {
+          total
+          perPage
+          currentPage
+          lastPage
+          hasNextPage
+        }
+        staff (search: $search, sort: [SEARCH_MATCH]) {
+          id
+          name {
+            first
+            middle
+            last
+            full
+            native
+            alternative
+          }
+          languageV2
+          image {
+            large
+            medium
+          }
+          primaryOccupations
+          favourites
+          siteUrl
+        }
+      }
+    }
+    `
+)
+
+type Staff struct {
+	ID                 int                       `json:"id"`
+	Name               PersonName                `json:"name"`
+	Language           string                    `json:"languageV2"`
+	Image              Image                     `json:"image"`
+	Description        string                    `json:"description"`
+	PrimaryOccupations []string                  `json:"primaryOccupations"`
+	Gender             string                    `json:"gender"`
+	DateOfBirth        FuzzyDate                 `json:"dateOfBirth"`
+	YearsActive        []int                     `json:"yearsActive"`
+	HomeTown           string                    `json:"homeTown"`
+	Favourites         int                       `json:"favourites"`
+	SiteURL            string                    `json:"siteUrl"`
+	StaffMedia         *StaffMediaConnection     `json:"staffMedia,omitempty"`
+	Characters         *StaffCharacterConnection `json:"characters,omitempty"`
+}
+
+// StaffMediaConnection is one page of the media a staff member worked on.
+type StaffMediaConnection struct {
+	PageInfo PageInfo         `json:"pageInfo"`
+	Edges    []StaffMediaEdge `json:"edges"`
+}
+
+// StaffMediaEdge is a media a staff member worked on, with their role in it,
+// such as "Director" or "Original Creator".
+type StaffMediaEdge struct {
+	StaffRole string `json:"staffRole"`
+	Node      Media  `json:"node"`
+}
+
+// StaffCharacterConnection is one page of the characters voiced by a staff
+// member.
+type StaffCharacterConnection struct {
+	PageInfo PageInfo             `json:"pageInfo"`
+	Edges    []StaffCharacterEdge `json:"edges"`
+}
+
+type StaffCharacterEdge struct {
+	Role string    `json:"role"`
+	Node Character `json:"node"`
+}
+
+// GetStaffByID retrieves a staff member along with one page of their media
+// roles, sorted by popularity, and one page of the characters they voiced,
+// sorted by favourites.
+//
+// Parameters:
+//   - id: The AniList ID of the staff member.
+//   - page: The page of media roles and characters to fetch, starting at 1.
+//   - perPage: The number of media roles and characters per page, at most 50.
+//
+// Returns:
+//   - The Staff, where StaffMedia and Characters hold the requested pages.
+//   - An error if there's any issue fetching the data or the staff member
+//     does not exist.
+func GetStaffByID(id int, page int, perPage int) (Staff, error) {
+	variables := map[string]interface{}{
+		"id":      id,
+		"page":    page,
+		"perPage": perPage,
+	}
+
+	data, err := sendRequest(BaseAPIURL, StaffQuery, variables, "")
+	if err != nil {
+		return Staff{}, err
+	}
+	if data.Data.Staff == nil {
+		return Staff{}, fmt.Errorf("staff %d not found", id)
+	}
+	return *data.Data.Staff, nil
+}
+
+// SearchStaff retrieves one page of staff members matching the search
+// string, best match first.
+func SearchStaff(search string, page int, perPage int) ([]Staff, PageInfo, error) {
+	variables := map[string]interface{}{
+		"search":  search,
+		"page":    page,
+		"perPage": perPage,
+	}
+
+	data, err := sendRequest(BaseAPIURL, StaffSearchQuery, variables, "")
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	if data.Data.Page == nil {
+		return nil, PageInfo{}, nil
+	}
+	return data.Data.Page.Staff, data.Data.Page.PageInfo, nil
+}
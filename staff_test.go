@@ -0,0 +1,66 @@
+package anilistgo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecodeStaff(t *testing.T) {
+	body := `{"data": {"Staff": {
+		"id": 95185,
+		"name": {"first": "Atsumi", "last": "Tanezaki", "full": "Atsumi Tanezaki", "native": "種﨑敦美", "alternative": []},
+		"languageV2": "Japanese",
+		"image": {"large": "https://s4.anilist.co/large.png", "medium": "https://s4.anilist.co/medium.png"},
+		"primaryOccupations": ["Voice Actor"],
+		"gender": "Female",
+		"dateOfBirth": {"year": 1988, "month": 9, "day": 27},
+		"yearsActive": [2009],
+		"homeTown": "Oita, Japan",
+		"favourites": 9000,
+		"siteUrl": "https://anilist.co/staff/95185",
+		"staffMedia": {
+			"pageInfo": {"total": 1, "perPage": 25, "currentPage": 1, "lastPage": 1, "hasNextPage": false},
+			"edges": [{"staffRole": "Theme Song Performance", "node": {"id": 154587, "type": "ANIME", "title": {"romaji": "Sousou no Frieren"}}}]
+		},
+		"characters": {
+			"pageInfo": {"total": 1, "perPage": 25, "currentPage": 1, "lastPage": 1, "hasNextPage": false},
+			"edges": [{"role": "MAIN", "node": {"id": 176754, "name": {"full": "Frieren"}}}]
+		}
+	}}}`
+
+	var response Response
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	staff := response.Data.Staff
+	if staff == nil || staff.ID != 95185 || staff.Name.Full != "Atsumi Tanezaki" || staff.Language != "Japanese" {
+		t.Fatalf("unexpected staff: %+v", staff)
+	}
+	if staff.DateOfBirth.Year == nil || *staff.DateOfBirth.Year != 1988 || len(staff.YearsActive) != 1 || staff.YearsActive[0] != 2009 {
+		t.Errorf("unexpected dates: %+v, %v", staff.DateOfBirth, staff.YearsActive)
+	}
+	if staff.StaffMedia == nil || len(staff.StaffMedia.Edges) != 1 || staff.StaffMedia.Edges[0].Node.ID != 154587 || staff.StaffMedia.Edges[0].StaffRole != "Theme Song Performance" {
+		t.Errorf("unexpected staff media: %+v", staff.StaffMedia)
+	}
+	if staff.Characters == nil || len(staff.Characters.Edges) != 1 || staff.Characters.Edges[0].Role != "MAIN" || staff.Characters.Edges[0].Node.Name.Full != "Frieren" {
+		t.Errorf("unexpected characters: %+v", staff.Characters)
+	}
+}
+
+func TestDecodeStaffSearch(t *testing.T) {
+	body := `{"data": {"Page": {
+		"pageInfo": {"total": 2, "perPage": 1, "currentPage": 1, "lastPage": 2, "hasNextPage": true},
+		"staff": [{"id": 95185, "name": {"full": "Atsumi Tanezaki"}, "primaryOccupations": ["Voice Actor"], "favourites": 9000}]
+	}}}`
+
+	var response Response
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	page := response.Data.Page
+	if page == nil || !page.PageInfo.HasNextPage || len(page.Staff) != 1 || page.Staff[0].PrimaryOccupations[0] != "Voice Actor" {
+		t.Errorf("unexpected page: %+v", page)
+	}
+}
@@ -0,0 +1,200 @@
+// Package stats computes statistics of AniList lists offline, from the
+// updates the anilistgo package already fetches, without further requests.
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Ithilias/anilistgo"
+)
+
+const (
+	DefaultStalledAfter    = 30 * 24 * time.Hour
+	DefaultEpisodeDuration = 24
+)
+
+// Options controls Compute. Now is the time the report is computed at and
+// defaults to the current time. Entries in progress that were not updated for
+// StalledAfter are reported as stalled. EpisodeDuration, in minutes, is used
+// for anime without a known episode duration.
+type Options struct {
+	Now             time.Time
+	StalledAfter    time.Duration
+	EpisodeDuration int
+}
+
+// Report holds the statistics of a list. Rates are fractions between 0 and 1
+// of the entries that were started, that is every entry except the planned
+// ones. MeanScore only counts scored entries and uses the POINT_100 format.
+type Report struct {
+	Total             int
+	ByStatus          map[string]int
+	CompletionRate    float64
+	DropRate          float64
+	ScoredCount       int
+	MeanScore         float64
+	ScoreDistribution []ScoreBucket
+	EpisodesWatched   int
+	ChaptersRead      int
+	VolumesRead       int
+	EstimatedMinutes  int
+	Velocity          []Period
+	Stalled           []StalledEntry
+}
+
+// ScoreBucket counts the entries with a score between Min and Max, both
+// included. The distribution always has ten buckets, from 1-10 to 91-100.
+type ScoreBucket struct {
+	Min   int
+	Max   int
+	Count int
+}
+
+// Period is the activity of one calendar month, starting at Start. As lists
+// only keep the time of the latest update of an entry, an entry counts towards
+// the month it was last updated in, with all its progress.
+type Period struct {
+	Start     time.Time
+	Updated   int
+	Completed int
+	Progress  int
+}
+
+// StalledEntry is an entry in progress that was not updated for Idle.
+type StalledEntry struct {
+	Update anilistgo.Update
+	Idle   time.Duration
+}
+
+// Compute computes the statistics of a list. Lists fetched with
+// GetMediaListCollection can be used by converting the entries with
+// MediaListEntry.ToUpdate. An entry that is on several custom lists, which
+// GetUpdates returns once per list, is only counted once.
+//
+// Usage:
+//
+//	updates, err := anilistgo.GetUpdates("Ithilias", anilistgo.MediaTypeAnime, nil, nil)
+//	report := stats.Compute(updates, stats.Options{StalledAfter: 14 * 24 * time.Hour})
+//	fmt.Printf("%.0f%% completed, %d hours watched\n", report.CompletionRate*100, report.EstimatedMinutes/60)
+//	for _, stalled := range report.Stalled {
+//	    fmt.Println("Stalled:", stalled.Update.Title)
+//	}
+func Compute(updates []anilistgo.Update, options Options) Report {
+	if options.Now.IsZero() {
+		options.Now = time.Now()
+	}
+	if options.StalledAfter <= 0 {
+		options.StalledAfter = DefaultStalledAfter
+	}
+	if options.EpisodeDuration <= 0 {
+		options.EpisodeDuration = DefaultEpisodeDuration
+	}
+
+	updates = uniqueUpdates(updates)
+	report := Report{
+		Total:             len(updates),
+		ByStatus:          make(map[string]int),
+		ScoreDistribution: make([]ScoreBucket, 10),
+	}
+	for i := range report.ScoreDistribution {
+		report.ScoreDistribution[i] = ScoreBucket{Min: i*10 + 1, Max: (i + 1) * 10}
+	}
+
+	scoreSum := 0
+	periods := make(map[time.Time]*Period)
+	for _, update := range updates {
+		report.ByStatus[update.Status]++
+
+		if update.Score > 0 && update.Score <= 100 {
+			report.ScoredCount++
+			scoreSum += update.Score
+			report.ScoreDistribution[(update.Score-1)/10].Count++
+		}
+
+		progress := intValue(update.Progress)
+		if update.MediaType == anilistgo.MediaTypeManga {
+			report.ChaptersRead += progress
+			report.VolumesRead += intValue(update.ProgressVol)
+		} else {
+			report.EpisodesWatched += progress
+			duration := intValue(update.Duration)
+			if duration <= 0 {
+				duration = options.EpisodeDuration
+			}
+			report.EstimatedMinutes += progress * duration
+		}
+
+		if update.UpdatedTime > 0 {
+			updatedAt := time.Unix(update.UpdatedTime, 0).UTC()
+			start := time.Date(updatedAt.Year(), updatedAt.Month(), 1, 0, 0, 0, 0, time.UTC)
+			period, ok := periods[start]
+			if !ok {
+				period = &Period{Start: start}
+				periods[start] = period
+			}
+			period.Updated++
+			period.Progress += progress
+			if update.Status == "COMPLETED" {
+				period.Completed++
+			}
+
+			idle := options.Now.Sub(updatedAt)
+			if isInProgress(update.Status) && idle >= options.StalledAfter {
+				report.Stalled = append(report.Stalled, StalledEntry{Update: update, Idle: idle})
+			}
+		}
+	}
+
+	if started := report.Total - report.ByStatus["PLANNING"]; started > 0 {
+		report.CompletionRate = float64(report.ByStatus["COMPLETED"]) / float64(started)
+		report.DropRate = float64(report.ByStatus["DROPPED"]) / float64(started)
+	}
+	if report.ScoredCount > 0 {
+		report.MeanScore = float64(scoreSum) / float64(report.ScoredCount)
+	}
+
+	for _, period := range periods {
+		report.Velocity = append(report.Velocity, *period)
+	}
+	sort.Slice(report.Velocity, func(i, j int) bool {
+		return report.Velocity[i].Start.Before(report.Velocity[j].Start)
+	})
+	sort.Slice(report.Stalled, func(i, j int) bool {
+		return report.Stalled[i].Idle > report.Stalled[j].Idle
+	})
+
+	return report
+}
+
+func isInProgress(status string) bool {
+	return status == "CURRENT" || status == "REPEATING" || status == "PAUSED"
+}
+
+func intValue(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+// uniqueUpdates returns the updates without the repeated entries of a media,
+// keeping the first one.
+func uniqueUpdates(updates []anilistgo.Update) []anilistgo.Update {
+	type key struct {
+		mediaType string
+		mediaID   int
+	}
+
+	seen := make(map[key]bool, len(updates))
+	unique := make([]anilistgo.Update, 0, len(updates))
+	for _, update := range updates {
+		k := key{update.MediaType, update.MediaID}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		unique = append(unique, update)
+	}
+	return unique
+}
@@ -0,0 +1,74 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ithilias/anilistgo"
+)
+
+func TestCompute(t *testing.T) {
+	now := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+	may := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC).Unix()
+	january := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC).Unix()
+	twelve, ten, thirty, fifty := 12, 10, 30, 50
+
+	updates := []anilistgo.Update{
+		{MediaID: 1, Status: "COMPLETED", Score: 85, Progress: &twelve, Duration: &thirty, UpdatedTime: may, MediaType: anilistgo.MediaTypeAnime},
+		{MediaID: 2, Status: "CURRENT", Score: 0, Progress: &ten, UpdatedTime: january, MediaType: anilistgo.MediaTypeAnime},
+		{MediaID: 3, Status: "DROPPED", Score: 40, Progress: &fifty, ProgressVol: &ten, UpdatedTime: may, MediaType: anilistgo.MediaTypeManga},
+		{MediaID: 4, Status: "PLANNING", MediaType: anilistgo.MediaTypeAnime},
+	}
+
+	report := Compute(updates, Options{Now: now})
+
+	if report.Total != 4 || report.ByStatus["COMPLETED"] != 1 {
+		t.Errorf("unexpected totals: %d %v", report.Total, report.ByStatus)
+	}
+	if report.CompletionRate != 1.0/3 || report.DropRate != 1.0/3 {
+		t.Errorf("expected rates of 1/3 but got %v and %v", report.CompletionRate, report.DropRate)
+	}
+	if report.ScoredCount != 2 || report.MeanScore != 62.5 {
+		t.Errorf("expected a mean score of 62.5 over 2 entries but got %v over %d", report.MeanScore, report.ScoredCount)
+	}
+	if report.ScoreDistribution[8].Count != 1 || report.ScoreDistribution[3].Count != 1 || report.ScoreDistribution[8].Min != 81 {
+		t.Errorf("unexpected score distribution: %+v", report.ScoreDistribution)
+	}
+	if report.EpisodesWatched != 22 || report.ChaptersRead != 50 || report.VolumesRead != 10 {
+		t.Errorf("unexpected progress totals: %d episodes, %d chapters, %d volumes", report.EpisodesWatched, report.ChaptersRead, report.VolumesRead)
+	}
+	if expected := 12*30 + 10*DefaultEpisodeDuration; report.EstimatedMinutes != expected {
+		t.Errorf("expected %d minutes but got %d", expected, report.EstimatedMinutes)
+	}
+
+	if len(report.Velocity) != 2 || report.Velocity[0].Start.Month() != time.January || report.Velocity[1].Updated != 2 || report.Velocity[1].Completed != 1 || report.Velocity[1].Progress != 62 {
+		t.Errorf("unexpected velocity: %+v", report.Velocity)
+	}
+	if len(report.Stalled) != 1 || report.Stalled[0].Update.MediaID != 2 {
+		t.Errorf("expected media 2 to be stalled but got %+v", report.Stalled)
+	}
+}
+
+func TestComputeCountsEntriesOfSeveralListsOnce(t *testing.T) {
+	twelve := 12
+	completed := anilistgo.Update{MediaID: 1, Status: "COMPLETED", Progress: &twelve, UpdatedTime: time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC).Unix(), MediaType: anilistgo.MediaTypeAnime}
+	updates := []anilistgo.Update{
+		completed,
+		{MediaID: 2, Status: "DROPPED", MediaType: anilistgo.MediaTypeAnime},
+		// The same entry, on a custom list.
+		completed,
+		// A manga sharing the ID of the anime is another entry.
+		{MediaID: 1, Status: "CURRENT", MediaType: anilistgo.MediaTypeManga},
+	}
+
+	report := Compute(updates, Options{})
+	if report.Total != 3 || report.ByStatus["COMPLETED"] != 1 {
+		t.Errorf("expected the repeated entry to be counted once but got %d %v", report.Total, report.ByStatus)
+	}
+	if report.EpisodesWatched != 12 || report.CompletionRate != 1.0/3 {
+		t.Errorf("expected 12 episodes and a completion rate of 1/3 but got %d and %v", report.EpisodesWatched, report.CompletionRate)
+	}
+	if len(report.Velocity) == 0 || report.Velocity[len(report.Velocity)-1].Updated != 1 {
+		t.Errorf("expected the repeated entry to count once towards its month but got %+v", report.Velocity)
+	}
+}
@@ -0,0 +1,135 @@
+package anilistgo
+
+import (
+	"fmt"
+)
+
+const (
+	StudioQuery = `
+    query ($id: Int, $page: Int, $perPage: Int) {
+      Studio (id: $id) {
+        id
+        name
+        isAnimationStudio
+        favourites
+        siteUrl
+        media (page: $page, perPage: $perPage, sort: START_DATE_DESC) {
+          pageInfo {
+            total
+            perPage
+            currentPage
+            lastPage
+            hasNextPage
+          }
+          edges {
+            isMainStudio
+            node {
+              id
+              type
+              format
+              title {
+                romaji
+                english
+                native
+              }
+              coverImage {
+                extraLarge
+              }
+            }
+          }
+        }
+      }
+    }
+    `
+
+	StudioSearchQuery = `
+    query ($search: String, $page: Int, $perPage: Int) {
+      Page (page: $page, perPage: $perPage) {
+        pageInfo {
+          total
+          perPage
+          currentPage
+          lastPage
+          hasNextPage
+        }
+        studios (search: $search, sort: [SEARCH_MATCH]) {
+          id
+          name
+          isAnimationStudio
+          favourites
+          siteUrl
+        }
+      }
+    }
+    `
+)
+
+type Studio struct {
+	ID                int                    `json:"id"`
+	Name              string                 `json:"name"`
+	IsAnimationStudio bool                   `json:"isAnimationStudio"`
+	Favourites        int                    `json:"favourites"`
+	SiteURL           string                 `json:"siteUrl"`
+	Media             *StudioMediaConnection `json:"media,omitempty"`
+}
+
+// StudioMediaConnection is one page of the productions of a studio.
+type StudioMediaConnection struct {
+	PageInfo PageInfo          `json:"pageInfo"`
+	Edges    []StudioMediaEdge `json:"edges"`
+}
+
+// StudioMediaEdge is a production of a studio. IsMainStudio is false if the
+// studio only assisted on it.
+type StudioMediaEdge struct {
+	IsMainStudio bool  `json:"isMainStudio"`
+	Node         Media `json:"node"`
+}
+
+// GetStudioByID retrieves a studio along with one page of its productions,
+// newest first.
+//
+// Parameters:
+//   - id: The AniList ID of the studio.
+//   - page: The page of productions to fetch, starting at 1.
+//   - perPage: The number of productions per page, at most 50.
+//
+// Returns:
+//   - The Studio, where Media holds the requested page of productions.
+//   - An error if there's any issue fetching the data or the studio does not
+//     exist.
+func GetStudioByID(id int, page int, perPage int) (Studio, error) {
+	variables := map[string]interface{}{
+		"id":      id,
+		"page":    page,
+		"perPage": perPage,
+	}
+
+	data, err := sendRequest(BaseAPIURL, StudioQuery, variables, "")
+	if err != nil {
+		return Studio{}, err
+	}
+	if data.Data.Studio == nil {
+		return Studio{}, fmt.Errorf("studio %d not found", id)
+	}
+	return *data.Data.Studio, nil
+}
+
+// SearchStudios retrieves one page of studios matching the search string,
+// best match first.
+func SearchStudios(search string, page int, perPage int) ([]Studio, PageInfo, error) {
+	variables := map[string]interface{}{
+		"search":  search,
+		"page":    page,
+		"perPage": perPage,
+	}
+
+	data, err := sendRequest(BaseAPIURL, StudioSearchQuery, variables, "")
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	if data.Data.Page == nil {
+		return nil, PageInfo{}, nil
+	}
+	return data.Data.Page.Studios, data.Data.Page.PageInfo, nil
+}
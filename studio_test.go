@@ -0,0 +1,59 @@
+package anilistgo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecodeStudio(t *testing.T) {
+	body := `{"data": {"Studio": {
+		"id": 11,
+		"name": "MADHOUSE",
+		"isAnimationStudio": true,
+		"favourites": 20000,
+		"siteUrl": "https://anilist.co/studio/11",
+		"media": {
+			"pageInfo": {"total": 2, "perPage": 25, "currentPage": 1, "lastPage": 1, "hasNextPage": false},
+			"edges": [
+				{"isMainStudio": true, "node": {"id": 154587, "type": "ANIME", "format": "TV", "title": {"romaji": "Sousou no Frieren"}}},
+				{"isMainStudio": false, "node": {"id": 1, "type": "ANIME"}}
+			]
+		}
+	}}}`
+
+	var response Response
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	studio := response.Data.Studio
+	if studio == nil || studio.ID != 11 || studio.Name != "MADHOUSE" || !studio.IsAnimationStudio || studio.Favourites != 20000 {
+		t.Fatalf("unexpected studio: %+v", studio)
+	}
+	if studio.Media == nil || studio.Media.PageInfo.Total != 2 || len(studio.Media.Edges) != 2 {
+		t.Fatalf("unexpected productions: %+v", studio.Media)
+	}
+	if edge := studio.Media.Edges[0]; !edge.IsMainStudio || edge.Node.ID != 154587 || edge.Node.Title.Romaji != "Sousou no Frieren" {
+		t.Errorf("unexpected main production: %+v", edge)
+	}
+	if studio.Media.Edges[1].IsMainStudio {
+		t.Error("expected the second production to be assisted on")
+	}
+}
+
+func TestDecodeStudioSearch(t *testing.T) {
+	body := `{"data": {"Page": {
+		"pageInfo": {"total": 1, "perPage": 10, "currentPage": 1, "lastPage": 1, "hasNextPage": false},
+		"studios": [{"id": 11, "name": "MADHOUSE", "isAnimationStudio": true}]
+	}}}`
+
+	var response Response
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	page := response.Data.Page
+	if page == nil || len(page.Studios) != 1 || page.Studios[0].Name != "MADHOUSE" {
+		t.Errorf("unexpected page: %+v", page)
+	}
+}
@@ -0,0 +1,499 @@
+package anilistgo
+
+import (
+	"fmt"
+)
+
+const (
+	ThreadsQuery = `
+    query ($page: Int, $perPage: Int, $search: String, $categoryId: Int, $mediaCategoryId: Int, $userId: Int, $subscribed: Boolean) {
+      Page (page: $page, perPage: $perPage) {
+        pageInfo {
+          total
+          perPage
+          currentPage
+          lastPage
+          hasNextPage
+        }
+        threads (search: $search, categoryId: $categoryId, mediaCategoryId: $mediaCategoryId, userId: $userId, subscribed: $subscribed, sort: [IS_STICKY, REPLIED_AT_DESC]) {
+          id
+          title
+          userId
+          replyUserId
+          replyCommentId
+          replyCount
+          viewCount
+          isLocked
+          isSticky
+          isSubscribed
+          likeCount
+          isLiked
+          repliedAt
+          createdAt
+          updatedAt
+          siteUrl
+          user {
+            id
+            name
+          }
+          replyUser {
+            id
+            name
+          }
+          categories {
+            id
+            name
+          }
+          mediaCategories {
+            id
+            type
+            title {
+              romaji
+              english
+              native
+            }
+          }
+        }
+      }
+    }
+    `
+
+	ThreadQuery = `
+    query ($id: Int, $asHtml: Boolean) {
+      Thread (id: $id) {
+        id
+        title
+        body (asHtml: $asHtml)
+        userId
+        replyUserId
+        replyCommentId
+        replyCount
+        viewCount
+        isLocked
+        isSticky
+        isSubscribed
+        likeCount
+        isLiked
+        repliedAt
+        createdAt
+        updatedAt
+        siteUrl
+        user {
+          id
+          name
+        }
+        replyUser {
+          id
+          name
+        }
+        categories {
+          id
+          name
+        }
+        mediaCategories {
+          id
+          type
+          title {
+            romaji
+            english
+            native
+          }
+        }
+      }
+    }
+    `
+
+	ThreadCommentsQuery = `
+    query ($threadId: Int, $page: Int, $perPage: Int, $asHtml: Boolean) {
+      Page (page: $page, perPage: $perPage) {
+        pageInfo {
+          total
+          perPage
+          currentPage
+          lastPage
+          hasNextPage
+        }
+        threadComments (threadId: $threadId, sort: [ID]) {
+          id
+          userId
+          threadId
+          comment (asHtml: $asHtml)
+          likeCount
+          isLiked
+          isLocked
+          siteUrl
+          createdAt
+          updatedAt
+          user {
+            id
+            name
+          }
+          childComments
+        }
+      }
+    }
+    `
+
+	SaveThreadQuery = `
+    mutation ($id: Int, $title: String, $body: String, $categories: [Int], $mediaCategories: [Int], $sticky: Boolean, $locked: Boolean) {
+      SaveThread (id: $id, title: $title, body: $body, categories: $categories, mediaCategories: $mediaCategories, sticky: $sticky, locked: $locked) {
+        id
+        title
+        body
+        userId
+        createdAt
+        updatedAt
+        siteUrl
+      }
+    }
+    `
+
+	DeleteThreadQuery = `
+    mutation ($id: Int) {
+      DeleteThread (id: $id) {
+        deleted
+      }
+    }
+    `
+
+	SaveThreadCommentQuery = `
+    mutation ($id: Int, $threadId: Int, $parentCommentId: Int, $comment: String) {
+      SaveThreadComment (id: $id, threadId: $threadId, parentCommentId: $parentCommentId, comment: $comment) {
+        id
+        userId
+        threadId
+        comment
+        siteUrl
+        createdAt
+        updatedAt
+      }
+    }
+    `
+
+	DeleteThreadCommentQuery = `
+    mutation ($id: Int) {
+      DeleteThreadComment (id: $id) {
+        deleted
+      }
+    }
+    `
+
+	ToggleThreadSubscriptionQuery = `
+    mutation ($threadId: Int, $subscribe: Boolean) {
+      ToggleThreadSubscription (threadId: $threadId, subscribe: $subscribe) {
+        id
+        isSubscribed
+      }
+    }
+    `
+)
+
+// Thread is a forum thread. Body is only set when fetching a single thread.
+// MediaCategories are the media the thread is about.
+type Thread struct {
+	ID              int              `json:"id"`
+	Title           string           `json:"title"`
+	Body            string           `json:"body,omitempty"`
+	UserID          int              `json:"userId"`
+	ReplyUserID     int              `json:"replyUserId"`
+	ReplyCommentID  int              `json:"replyCommentId"`
+	ReplyCount      int              `json:"replyCount"`
+	ViewCount       int              `json:"viewCount"`
+	IsLocked        bool             `json:"isLocked"`
+	IsSticky        bool             `json:"isSticky"`
+	IsSubscribed    bool             `json:"isSubscribed"`
+	LikeCount       int              `json:"likeCount"`
+	IsLiked         bool             `json:"isLiked"`
+	RepliedAt       int64            `json:"repliedAt"`
+	CreatedAt       int64            `json:"createdAt"`
+	UpdatedAt       int64            `json:"updatedAt"`
+	SiteURL         string           `json:"siteUrl"`
+	User            *UserInfo        `json:"user,omitempty"`
+	ReplyUser       *UserInfo        `json:"replyUser,omitempty"`
+	Categories      []ThreadCategory `json:"categories"`
+	MediaCategories []Media          `json:"mediaCategories"`
+}
+
+type ThreadCategory struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// ThreadComment is a comment on a forum thread. ChildComments holds the
+// replies to the comment, which can have replies of their own.
+type ThreadComment struct {
+	ID            int             `json:"id"`
+	UserID        int             `json:"userId"`
+	ThreadID      int             `json:"threadId"`
+	Comment       string          `json:"comment"`
+	LikeCount     int             `json:"likeCount"`
+	IsLiked       bool            `json:"isLiked"`
+	IsLocked      bool            `json:"isLocked"`
+	SiteURL       string          `json:"siteUrl"`
+	CreatedAt     int64           `json:"createdAt"`
+	UpdatedAt     int64           `json:"updatedAt"`
+	User          *UserInfo       `json:"user,omitempty"`
+	ChildComments []ThreadComment `json:"childComments,omitempty"`
+}
+
+// ThreadFilter narrows down the threads returned by GetThreads. Zero values
+// are not sent to the API.
+type ThreadFilter struct {
+	Search          string
+	CategoryID      int
+	MediaCategoryID int
+	UserID          int
+	Subscribed      bool
+}
+
+// SaveThreadOp describes a thread to create or update with SaveThread. ID is
+// the ID of the thread to update, or zero to create a new thread.
+// Categories are the IDs of forum categories and MediaCategories the IDs of
+// the media the thread is about.
+type SaveThreadOp struct {
+	ID              int
+	Title           string
+	Body            string
+	Categories      []int
+	MediaCategories []int
+}
+
+// GetThreads retrieves one page of forum threads matching the given filter,
+// sticky threads first, then the most recently replied to.
+//
+// Parameters:
+//   - filter: The ThreadFilter to apply. Subscribed only has an effect for
+//     authenticated requests, see AuthenticatedAPI.GetThreads.
+//   - page: The page to fetch, starting at 1.
+//   - perPage: The number of threads per page, at most 50.
+//
+// Returns:
+//   - A slice of Thread structs, without their body.
+//   - The PageInfo of the fetched page, to know whether there is a next page.
+//   - An error if there's any issue fetching the data.
+//
+// Usage:
+//
+//	threads, _, err := GetThreads(ThreadFilter{MediaCategoryID: 21}, 1, PerPage)
+//	for _, thread := range threads {
+//	    fmt.Printf("%s (%d replies)\n", thread.Title, thread.ReplyCount)
+//	}
+func GetThreads(filter ThreadFilter, page int, perPage int) ([]Thread, PageInfo, error) {
+	return fetchThreads(filter, page, perPage, "")
+}
+
+// GetThreads retrieves one page of forum threads like the package level
+// GetThreads, but as the authenticated user. This is required for the
+// Subscribed filter, which restricts the threads to the ones the
+// authenticated user is subscribed to.
+func (api *AuthenticatedAPI) GetThreads(filter ThreadFilter, page int, perPage int) ([]Thread, PageInfo, error) {
+	return fetchThreads(filter, page, perPage, api.AccessToken)
+}
+
+// GetThread retrieves a single forum thread along with its body, as markdown
+// or as HTML if asHTML is true.
+func GetThread(id int, asHTML bool) (Thread, error) {
+	variables := map[string]interface{}{
+		"id":     id,
+		"asHtml": asHTML,
+	}
+
+	data, err := sendRequest(BaseAPIURL, ThreadQuery, variables, "")
+	if err != nil {
+		return Thread{}, err
+	}
+	if data.Data.Thread == nil {
+		return Thread{}, fmt.Errorf("thread %d not found", id)
+	}
+	return *data.Data.Thread, nil
+}
+
+// GetThreadComments retrieves one page of the top level comments of a forum
+// thread, oldest first, each with its nested replies in ChildComments.
+//
+// Usage:
+//
+//	comments, err := CollectPages(func(page int) ([]ThreadComment, PageInfo, error) {
+//	    return GetThreadComments(4446, false, page, MaxPerPage)
+//	})
+func GetThreadComments(threadID int, asHTML bool, page int, perPage int) ([]ThreadComment, PageInfo, error) {
+	variables := map[string]interface{}{
+		"threadId": threadID,
+		"asHtml":   asHTML,
+		"page":     page,
+		"perPage":  perPage,
+	}
+
+	data, err := sendRequest(BaseAPIURL, ThreadCommentsQuery, variables, "")
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	if data.Data.Page == nil {
+		return nil, PageInfo{}, nil
+	}
+	return data.Data.Page.ThreadComments, data.Data.Page.PageInfo, nil
+}
+
+// SaveThread creates or updates a forum thread of the authenticated user, and
+// returns the saved thread.
+//
+// Usage:
+//
+//	thread, err := api.SaveThread(SaveThreadOp{Title: "Episode 1 discussion", Body: body, Categories: []int{1}, MediaCategories: []int{21}})
+func (api *AuthenticatedAPI) SaveThread(op SaveThreadOp) (Thread, error) {
+	variables := map[string]interface{}{
+		"title": op.Title,
+		"body":  op.Body,
+	}
+	if op.ID != 0 {
+		variables["id"] = op.ID
+	}
+	if op.Categories != nil {
+		variables["categories"] = op.Categories
+	}
+	if op.MediaCategories != nil {
+		variables["mediaCategories"] = op.MediaCategories
+	}
+
+	data, err := sendRequest(BaseAPIURL, SaveThreadQuery, variables, api.AccessToken)
+	if err != nil {
+		return Thread{}, err
+	}
+	if data.Data.SaveThread == nil {
+		return Thread{}, fmt.Errorf("thread %q was not saved", op.Title)
+	}
+	return *data.Data.SaveThread, nil
+}
+
+// DeleteThread deletes a forum thread of the authenticated user.
+func (api *AuthenticatedAPI) DeleteThread(id int) error {
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	data, err := sendRequest(BaseAPIURL, DeleteThreadQuery, variables, api.AccessToken)
+	if err != nil {
+		return err
+	}
+	if data.Data.DeleteThread == nil || !data.Data.DeleteThread.Deleted {
+		return fmt.Errorf("thread %d was not deleted", id)
+	}
+	return nil
+}
+
+// PostThreadComment posts a comment on a forum thread as the authenticated
+// user. The parentCommentID is the ID of the comment to reply to, or zero for
+// a top level comment.
+//
+// Usage:
+//
+//	comment, err := api.PostThreadComment(4446, 0, "Great episode!")
+func (api *AuthenticatedAPI) PostThreadComment(threadID int, parentCommentID int, text string) (ThreadComment, error) {
+	variables := map[string]interface{}{
+		"threadId": threadID,
+		"comment":  text,
+	}
+	if parentCommentID != 0 {
+		variables["parentCommentId"] = parentCommentID
+	}
+	return api.saveThreadComment(variables)
+}
+
+// EditThreadComment replaces the text of a comment of the authenticated
+// user.
+func (api *AuthenticatedAPI) EditThreadComment(id int, text string) (ThreadComment, error) {
+	variables := map[string]interface{}{
+		"id":      id,
+		"comment": text,
+	}
+	return api.saveThreadComment(variables)
+}
+
+// DeleteThreadComment deletes a comment of the authenticated user.
+func (api *AuthenticatedAPI) DeleteThreadComment(id int) error {
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	data, err := sendRequest(BaseAPIURL, DeleteThreadCommentQuery, variables, api.AccessToken)
+	if err != nil {
+		return err
+	}
+	if data.Data.DeleteThreadComment == nil || !data.Data.DeleteThreadComment.Deleted {
+		return fmt.Errorf("thread comment %d was not deleted", id)
+	}
+	return nil
+}
+
+// ToggleThreadSubscription subscribes the authenticated user to a forum
+// thread, or unsubscribes them if subscribe is false.
+func (api *AuthenticatedAPI) ToggleThreadSubscription(threadID int, subscribe bool) error {
+	variables := map[string]interface{}{
+		"threadId":  threadID,
+		"subscribe": subscribe,
+	}
+
+	data, err := sendRequest(BaseAPIURL, ToggleThreadSubscriptionQuery, variables, api.AccessToken)
+	if err != nil {
+		return err
+	}
+	if data.Data.ToggleThreadSubscription == nil || data.Data.ToggleThreadSubscription.IsSubscribed != subscribe {
+		return fmt.Errorf("subscription to thread %d was not updated", threadID)
+	}
+	return nil
+}
+
+// Walk calls fn for the comment and then for every nested reply, depth first,
+// with the depth of each comment, starting at 0.
+func (c ThreadComment) Walk(fn func(comment ThreadComment, depth int)) {
+	c.walk(fn, 0)
+}
+
+func (c ThreadComment) walk(fn func(comment ThreadComment, depth int), depth int) {
+	fn(c, depth)
+	for _, child := range c.ChildComments {
+		child.walk(fn, depth+1)
+	}
+}
+
+func (api *AuthenticatedAPI) saveThreadComment(variables map[string]interface{}) (ThreadComment, error) {
+	data, err := sendRequest(BaseAPIURL, SaveThreadCommentQuery, variables, api.AccessToken)
+	if err != nil {
+		return ThreadComment{}, err
+	}
+	if data.Data.SaveThreadComment == nil {
+		return ThreadComment{}, fmt.Errorf("thread comment was not saved")
+	}
+	return *data.Data.SaveThreadComment, nil
+}
+
+func fetchThreads(filter ThreadFilter, page int, perPage int, accessToken string) ([]Thread, PageInfo, error) {
+	variables := map[string]interface{}{
+		"page":    page,
+		"perPage": perPage,
+	}
+	if filter.Search != "" {
+		variables["search"] = filter.Search
+	}
+	if filter.CategoryID != 0 {
+		variables["categoryId"] = filter.CategoryID
+	}
+	if filter.MediaCategoryID != 0 {
+		variables["mediaCategoryId"] = filter.MediaCategoryID
+	}
+	if filter.UserID != 0 {
+		variables["userId"] = filter.UserID
+	}
+	if filter.Subscribed {
+		variables["subscribed"] = true
+	}
+
+	data, err := sendRequest(BaseAPIURL, ThreadsQuery, variables, accessToken)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	if data.Data.Page == nil {
+		return nil, PageInfo{}, nil
+	}
+	return data.Data.Page.Threads, data.Data.Page.PageInfo, nil
+}
@@ -0,0 +1,41 @@
+package anilistgo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecodeNestedThreadComments(t *testing.T) {
+	body := `{"data": {"Page": {"threadComments": [{
+		"id": 1,
+		"comment": "First",
+		"user": {"id": 10, "name": "Ithilias"},
+		"childComments": [
+			{"id": 2, "comment": "Reply", "childComments": [{"id": 3, "comment": "Nested reply"}]},
+			{"id": 4, "comment": "Second reply", "childComments": null}
+		]
+	}]}}}`
+
+	var response Response
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	comments := response.Data.Page.ThreadComments
+	if len(comments) != 1 || comments[0].User == nil || comments[0].User.Name != "Ithilias" {
+		t.Fatalf("unexpected comments: %+v", comments)
+	}
+
+	var ids, depths []int
+	comments[0].Walk(func(comment ThreadComment, depth int) {
+		ids = append(ids, comment.ID)
+		depths = append(depths, depth)
+	})
+
+	expectedIDs, expectedDepths := []int{1, 2, 3, 4}, []int{0, 1, 2, 1}
+	for i := range expectedIDs {
+		if i >= len(ids) || ids[i] != expectedIDs[i] || depths[i] != expectedDepths[i] {
+			t.Fatalf("expected IDs %v at depths %v but got %v at %v", expectedIDs, expectedDepths, ids, depths)
+		}
+	}
+}
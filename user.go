@@ -0,0 +1,409 @@
+package anilistgo
+
+import (
+	"fmt"
+)
+
+const (
+	UserProfileQuery = `
+    query ($name: String) {
+      User (name: $name) {
+        id
+        name
+        about (asHtml: false)
+        avatar {
+          large
+          medium
+        }
+        bannerImage
+        siteUrl
+        donatorTier
+        donatorBadge
+        createdAt
+        updatedAt
+        options {
+          titleLanguage
+          displayAdultContent
+          airingNotifications
+          profileColor
+          timezone
+          activityMergeTime
+          staffNameLanguage
+        }
+        favourites {
+          anime {
+            pageInfo {
+              total
+              perPage
+              currentPage
+              lastPage
+              hasNextPage
+            }
+            edges {
+              favouriteOrder
+              node {
+                id
+                type
+                format
+                title {
+                  romaji
+                  english
+                  native
+                }
+                coverImage {
+                  extraLarge
+                }
+                siteUrl
+              }
+            }
+          }
+          manga {
+            pageInfo {
+              total
+              perPage
+              currentPage
+              lastPage
+              hasNextPage
+            }
+            edges {
+              favouriteOrder
+              node {
+                id
+                type
+                format
+                title {
+                  romaji
+                  english
+                  native
+                }
+                coverImage {
+                  extraLarge
+                }
+                siteUrl
+              }
+            }
+          }
+          characters {
+            pageInfo {
+              total
+              perPage
+              currentPage
+              lastPage
+              hasNextPage
+            }
+            edges {
+              favouriteOrder
+              node {
+                id
+                name {
+                  full
+                  native
+                }
+                image {
+                  large
+                  medium
+                }
+                siteUrl
+              }
+            }
+          }
+          staff {
+            pageInfo {
+              total
+              perPage
+              currentPage
+              lastPage
+              hasNextPage
+            }
+            edges {
+              favouriteOrder
+              node {
+                id
+                name {
+                  full
+                  native
+                }
+                image {
+                  large
+                  medium
+                }
+                siteUrl
+              }
+            }
+          }
+          studios {
+            pageInfo {
+              total
+              perPage
+              currentPage
+              lastPage
+              hasNextPage
+            }
+            edges {
+              favouriteOrder
+              node {
+                id
+                name
+                siteUrl
+              }
+            }
+          }
+        }
+        statistics {
+          anime {
+            ...UserStatisticsFields
+          }
+          manga {
+            ...UserStatisticsFields
+          }
+        }
+      }
+    }
+
+    fragment UserStatisticsFields on UserStatistics {
+      count
+      meanScore
+      standardDeviation
+      minutesWatched
+      episodesWatched
+      chaptersRead
+      volumesRead
+      genres (sort: COUNT_DESC) {
+        count
+        meanScore
+        minutesWatched
+        chaptersRead
+        mediaIds
+        genre
+      }
+      tags (sort: COUNT_DESC, limit: 30) {
+        count
+        meanScore
+        minutesWatched
+        chaptersRead
+        mediaIds
+        tag {
+          id
+          name
+        }
+      }
+      formats (sort: COUNT_DESC) {
+        count
+        meanScore
+        minutesWatched
+        chaptersRead
+        mediaIds
+        format
+      }
+      statuses (sort: COUNT_DESC) {
+        count
+        meanScore
+        minutesWatched
+        chaptersRead
+        mediaIds
+        status
+      }
+      releaseYears (sort: ID_DESC) {
+        count
+        meanScore
+        minutesWatched
+        chaptersRead
+        mediaIds
+        releaseYear
+      }
+      studios (sort: COUNT_DESC, limit: 30) {
+        count
+        meanScore
+        minutesWatched
+        chaptersRead
+        mediaIds
+        studio {
+          id
+          name
+        }
+      }
+      staff (sort: COUNT_DESC, limit: 30) {
+        count
+        meanScore
+        minutesWatched
+        chaptersRead
+        mediaIds
+        staff {
+          id
+          name {
+            full
+            native
+          }
+        }
+      }
+    }
+    `
+)
+
+// User is the full profile of an AniList user. Options are the user's site
+// settings, Favourites holds the first page of each kind of favourite, and
+// Statistics the server side statistics of the user's lists.
+type User struct {
+	ID           int                 `json:"id"`
+	Name         string              `json:"name,omitempty"`
+	About        string              `json:"about,omitempty"`
+	Avatar       Image               `json:"avatar"`
+	BannerImage  string              `json:"bannerImage,omitempty"`
+	SiteURL      string              `json:"siteUrl,omitempty"`
+	DonatorTier  int                 `json:"donatorTier,omitempty"`
+	DonatorBadge string              `json:"donatorBadge,omitempty"`
+	CreatedAt    int64               `json:"createdAt,omitempty"`
+	UpdatedAt    int64               `json:"updatedAt,omitempty"`
+	Options      *UserOptions        `json:"options,omitempty"`
+	Favourites   *Favourites         `json:"favourites,omitempty"`
+	Statistics   *UserStatisticTypes `json:"statistics,omitempty"`
+}
+
+type UserOptions struct {
+	TitleLanguage       string `json:"titleLanguage"`
+	DisplayAdultContent bool   `json:"displayAdultContent"`
+	AiringNotifications bool   `json:"airingNotifications"`
+	ProfileColor        string `json:"profileColor"`
+	Timezone            string `json:"timezone"`
+	ActivityMergeTime   int    `json:"activityMergeTime"`
+	StaffNameLanguage   string `json:"staffNameLanguage"`
+}
+
+// Favourites holds a user's favourites of every kind, in the order chosen by
+// the user, which is the FavouriteOrder of the edges.
+type Favourites struct {
+	Anime      *FavouriteMediaConnection `json:"anime,omitempty"`
+	Manga      *FavouriteMediaConnection `json:"manga,omitempty"`
+	Characters *CharacterConnection      `json:"characters,omitempty"`
+	Staff      *StaffConnection          `json:"staff,omitempty"`
+	Studios    *StudioConnection         `json:"studios,omitempty"`
+}
+
+type FavouriteMediaConnection struct {
+	PageInfo PageInfo             `json:"pageInfo"`
+	Edges    []FavouriteMediaEdge `json:"edges"`
+}
+
+type FavouriteMediaEdge struct {
+	FavouriteOrder int   `json:"favouriteOrder"`
+	Node           Media `json:"node"`
+}
+
+type CharacterConnection struct {
+	PageInfo PageInfo        `json:"pageInfo"`
+	Edges    []CharacterEdge `json:"edges"`
+}
+
+type CharacterEdge struct {
+	FavouriteOrder int       `json:"favouriteOrder"`
+	Node           Character `json:"node"`
+}
+
+type StaffConnection struct {
+	PageInfo PageInfo    `json:"pageInfo"`
+	Edges    []StaffEdge `json:"edges"`
+}
+
+type StaffEdge struct {
+	FavouriteOrder int   `json:"favouriteOrder"`
+	Node           Staff `json:"node"`
+}
+
+type StudioConnection struct {
+	PageInfo PageInfo     `json:"pageInfo"`
+	Edges    []StudioEdge `json:"edges"`
+}
+
+type StudioEdge struct {
+	FavouriteOrder int    `json:"favouriteOrder"`
+	Node           Studio `json:"node"`
+}
+
+type UserStatisticTypes struct {
+	Anime UserStatistics `json:"anime"`
+	Manga UserStatistics `json:"manga"`
+}
+
+// UserStatistics are the statistics of a user's anime or manga list. Only the
+// fields matching the media type are set, so MinutesWatched and
+// EpisodesWatched are zero for manga, and ChaptersRead and VolumesRead for
+// anime. MeanScore uses the POINT_100 format.
+type UserStatistics struct {
+	Count             int                    `json:"count"`
+	MeanScore         float64                `json:"meanScore"`
+	StandardDeviation float64                `json:"standardDeviation"`
+	MinutesWatched    int                    `json:"minutesWatched"`
+	EpisodesWatched   int                    `json:"episodesWatched"`
+	ChaptersRead      int                    `json:"chaptersRead"`
+	VolumesRead       int                    `json:"volumesRead"`
+	Genres            []UserStatisticsBucket `json:"genres"`
+	Tags              []UserStatisticsBucket `json:"tags"`
+	Formats           []UserStatisticsBucket `json:"formats"`
+	Statuses          []UserStatisticsBucket `json:"statuses"`
+	ReleaseYears      []UserStatisticsBucket `json:"releaseYears"`
+	Studios           []UserStatisticsBucket `json:"studios"`
+	Staff             []UserStatisticsBucket `json:"staff"`
+}
+
+// UserStatisticsBucket is one entry of a breakdown of UserStatistics. Only the
+// key of its breakdown is set: Genre for Genres, Tag for Tags and so on.
+type UserStatisticsBucket struct {
+	Count          int       `json:"count"`
+	MeanScore      float64   `json:"meanScore"`
+	MinutesWatched int       `json:"minutesWatched"`
+	ChaptersRead   int       `json:"chaptersRead"`
+	MediaIDs       []int     `json:"mediaIds"`
+	Genre          string    `json:"genre,omitempty"`
+	Tag            *MediaTag `json:"tag,omitempty"`
+	Format         string    `json:"format,omitempty"`
+	Status         string    `json:"status,omitempty"`
+	ReleaseYear    int       `json:"releaseYear,omitempty"`
+	Studio         *Studio   `json:"studio,omitempty"`
+	Staff          *Staff    `json:"staff,omitempty"`
+}
+
+type MediaTag struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// GetUser retrieves the full profile of a user, including their favourites
+// and the statistics of their anime and manga lists.
+//
+// Parameters:
+//   - name: The AniList username.
+//
+// Returns:
+//   - The User.
+//   - An error if there's any issue fetching the data or the user does not
+//     exist.
+//
+// Usage:
+//
+//	user, err := GetUser("Ithilias")
+//	if user.Statistics != nil {
+//	    anime := user.Statistics.Anime
+//	    fmt.Printf("%d anime, %.1f days watched\n", anime.Count, anime.DaysWatched())
+//	    for _, genre := range anime.Genres {
+//	        fmt.Printf("%s: %d\n", genre.Genre, genre.Count)
+//	    }
+//	}
+func GetUser(name string) (User, error) {
+	variables := map[string]interface{}{
+		"name": name,
+	}
+
+	data, err := sendRequest(BaseAPIURL, UserProfileQuery, variables, "")
+	if err != nil {
+		return User{}, err
+	}
+	if data.Data.User.ID == 0 {
+		return User{}, fmt.Errorf("user %s not found", name)
+	}
+	return data.Data.User, nil
+}
+
+// DaysWatched returns MinutesWatched in days, as shown on AniList profiles.
+func (s UserStatistics) DaysWatched() float64 {
+	return float64(s.MinutesWatched) / (24 * 60)
+}
@@ -0,0 +1,51 @@
+package anilistgo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecodeUserStatistics(t *testing.T) {
+	body := `{"data": {"User": {
+		"id": 1,
+		"name": "Ithilias",
+		"favourites": {"anime": {"edges": [{"favouriteOrder": 1, "node": {"id": 20}}]}},
+		"statistics": {
+			"anime": {
+				"count": 2,
+				"minutesWatched": 4320,
+				"genres": [{"count": 2, "mediaIds": [20, 21], "genre": "Action"}],
+				"tags": [{"count": 1, "tag": {"id": 5, "name": "Ninja"}}],
+				"studios": [{"count": 1, "studio": {"id": 1, "name": "Pierrot"}}]
+			},
+			"manga": {"count": 1, "chaptersRead": 100}
+		}
+	}}}`
+
+	var response Response
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	user := response.Data.User
+	if user.ID != 1 || user.Statistics == nil || user.Favourites == nil {
+		t.Fatalf("expected a user with statistics and favourites but got %+v", user)
+	}
+	if edges := user.Favourites.Anime.Edges; len(edges) != 1 || edges[0].Node.ID != 20 || edges[0].FavouriteOrder != 1 {
+		t.Errorf("unexpected favourite anime: %+v", edges)
+	}
+
+	anime := user.Statistics.Anime
+	if anime.DaysWatched() != 3 {
+		t.Errorf("expected 3 days watched but got %v", anime.DaysWatched())
+	}
+	if len(anime.Genres) != 1 || anime.Genres[0].Genre != "Action" || len(anime.Genres[0].MediaIDs) != 2 {
+		t.Errorf("unexpected genres: %+v", anime.Genres)
+	}
+	if anime.Tags[0].Tag == nil || anime.Tags[0].Tag.Name != "Ninja" || anime.Studios[0].Studio.Name != "Pierrot" {
+		t.Errorf("unexpected tag or studio breakdown: %+v %+v", anime.Tags, anime.Studios)
+	}
+	if user.Statistics.Manga.ChaptersRead != 100 {
+		t.Errorf("expected 100 chapters read but got %d", user.Statistics.Manga.ChaptersRead)
+	}
+}
@@ -0,0 +1,331 @@
+package anilistgo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// EventType is the kind of change an Event describes.
+type EventType string
+
+const (
+	EventAdded           EventType = "ADDED"
+	EventRemoved         EventType = "REMOVED"
+	EventProgressChanged EventType = "PROGRESS_CHANGED"
+	EventStatusChanged   EventType = "STATUS_CHANGED"
+	EventScoreChanged    EventType = "SCORE_CHANGED"
+)
+
+const DefaultWatchInterval = 5 * time.Minute
+
+// WatchedEntry is the last-seen state of a single list entry, as remembered by
+// a Watcher between polls.
+type WatchedEntry struct {
+	UpdatedTime int64  `json:"updatedTime"`
+	Status      string `json:"status"`
+	Score       int    `json:"score"`
+	Progress    *int   `json:"progress,omitempty"`
+	ProgressVol *int   `json:"progressVolumes,omitempty"`
+}
+
+// Event describes a change detected by a Watcher. Previous is nil for
+// EventAdded, and Current only carries the MediaID, UserName and MediaType
+// for EventRemoved.
+type Event struct {
+	Type     EventType
+	UserName string
+	MediaID  int
+	Previous *WatchedEntry
+	Current  Update
+}
+
+// StateStore persists the last-seen entries of every watched user. Load
+// returns a nil map if the user has never been saved before.
+type StateStore interface {
+	Load(userName string) (map[int]WatchedEntry, error)
+	Save(userName string, entries map[int]WatchedEntry) error
+}
+
+// Watcher periodically fetches the updates of a set of users and reports the
+// differences with the previously seen state as typed events. OnError is
+// called with the errors of a single user during Run, whose events are then
+// detected again by the next poll.
+type Watcher struct {
+	Users     []string
+	MediaType string
+	Interval  time.Duration
+	Store     StateStore
+	OnEvent   func(Event)
+	OnError   func(userName string, err error)
+
+	fetch func(username string, mediaType string) ([]Update, error)
+}
+
+// JSONFileStore is a StateStore that keeps the state of all users in a single
+// JSON file on disk.
+type JSONFileStore struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// NewWatcher creates a Watcher for the given users and media type, backed by
+// the provided StateStore. The Interval defaults to DefaultWatchInterval and
+// can be changed before calling Run.
+//
+// The first poll of a user without any saved state only records a baseline and
+// does not emit events, so existing lists are not reported as EventAdded.
+//
+// Usage:
+//
+//	store := &JSONFileStore{Path: "state.json"}
+//	watcher := NewWatcher([]string{"Ithilias"}, MediaTypeAnime, store)
+//	watcher.OnEvent = func(e Event) { fmt.Println(e.Type, e.Current.Title) }
+//	err := watcher.Run(ctx, nil)
+func NewWatcher(users []string, mediaType string, store StateStore) *Watcher {
+	return &Watcher{
+		Users:     users,
+		MediaType: mediaType,
+		Interval:  DefaultWatchInterval,
+		Store:     store,
+	}
+}
+
+// Run polls all users every Interval until the context is cancelled. Each
+// event is passed to OnEvent if it is set and sent to events if it is not nil.
+// The state of a user is only saved once all of their events were delivered,
+// so events are never lost. Errors of a single user, such as a failed fetch,
+// are passed to OnError and do not stop the watcher. Run returns the
+// context's error once it is done.
+func (w *Watcher) Run(ctx context.Context, events chan<- Event) error {
+	if w.Store == nil {
+		return errors.New("watcher has no state store")
+	}
+
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, user := range w.Users {
+			detected, current, err := w.pollUser(user)
+			if err == nil {
+				if err := w.deliver(ctx, detected, events); err != nil {
+					return err
+				}
+				err = w.Store.Save(user, current)
+			}
+			if err != nil && w.OnError != nil {
+				w.OnError(user, err)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Poll fetches the current updates of every user once, compares them with the
+// stored state, saves the new state and returns the detected events. It stops
+// at the first error and returns it along with the events of the users saved
+// before.
+func (w *Watcher) Poll() ([]Event, error) {
+	if w.Store == nil {
+		return nil, errors.New("watcher has no state store")
+	}
+
+	var events []Event
+	for _, user := range w.Users {
+		detected, current, err := w.pollUser(user)
+		if err != nil {
+			return events, err
+		}
+		if err := w.Store.Save(user, current); err != nil {
+			return events, err
+		}
+		events = append(events, detected...)
+	}
+
+	return events, nil
+}
+
+// pollUser returns the events of a user and their new state, without saving
+// it.
+func (w *Watcher) pollUser(user string) ([]Event, map[int]WatchedEntry, error) {
+	fetch := w.fetch
+	if fetch == nil {
+		fetch = func(username string, mediaType string) ([]Update, error) {
+			return GetUpdates(username, mediaType, nil, nil)
+		}
+	}
+
+	updates, err := fetch(user, w.MediaType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	previous, err := w.Store.Load(user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	current := make(map[int]WatchedEntry, len(updates))
+	for _, update := range updates {
+		current[update.MediaID] = watchedEntryFromUpdate(update)
+	}
+
+	if previous == nil {
+		return nil, current, nil
+	}
+	return diffWatchedEntries(user, w.MediaType, previous, updates), current, nil
+}
+
+func (w *Watcher) deliver(ctx context.Context, detected []Event, events chan<- Event) error {
+	for _, event := range detected {
+		if w.OnEvent != nil {
+			w.OnEvent(event)
+		}
+		if events != nil {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	return nil
+}
+
+// Load returns the saved entries of the given user, or nil if the file or the
+// user does not exist yet.
+func (s *JSONFileStore) Load(userName string) (map[int]WatchedEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return state[userName], nil
+}
+
+// Save replaces the saved entries of the given user and writes the file.
+func (s *JSONFileStore) Save(userName string, entries map[int]WatchedEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.read()
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		state = make(map[string]map[int]WatchedEntry)
+	}
+	state[userName] = entries
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := s.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.Path)
+}
+
+func (s *JSONFileStore) read() (map[string]map[int]WatchedEntry, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state map[string]map[int]WatchedEntry
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func watchedEntryFromUpdate(update Update) WatchedEntry {
+	return WatchedEntry{
+		UpdatedTime: update.UpdatedTime,
+		Status:      update.Status,
+		Score:       update.Score,
+		Progress:    update.Progress,
+		ProgressVol: update.ProgressVol,
+	}
+}
+
+func diffWatchedEntries(user, mediaType string, previous map[int]WatchedEntry, updates []Update) []Event {
+	var events []Event
+	seen := make(map[int]bool, len(updates))
+
+	for _, update := range updates {
+		seen[update.MediaID] = true
+
+		old, ok := previous[update.MediaID]
+		if !ok {
+			events = append(events, Event{Type: EventAdded, UserName: user, MediaID: update.MediaID, Current: update})
+			continue
+		}
+		if old.UpdatedTime == update.UpdatedTime {
+			continue
+		}
+
+		prev := old
+		if !equalIntPtr(old.Progress, update.Progress) || !equalIntPtr(old.ProgressVol, update.ProgressVol) {
+			events = append(events, Event{Type: EventProgressChanged, UserName: user, MediaID: update.MediaID, Previous: &prev, Current: update})
+		}
+		if old.Status != update.Status {
+			events = append(events, Event{Type: EventStatusChanged, UserName: user, MediaID: update.MediaID, Previous: &prev, Current: update})
+		}
+		if old.Score != update.Score {
+			events = append(events, Event{Type: EventScoreChanged, UserName: user, MediaID: update.MediaID, Previous: &prev, Current: update})
+		}
+	}
+
+	var removed []int
+	for mediaID := range previous {
+		if !seen[mediaID] {
+			removed = append(removed, mediaID)
+		}
+	}
+	sort.Ints(removed)
+
+	for _, mediaID := range removed {
+		prev := previous[mediaID]
+		events = append(events, Event{
+			Type:     EventRemoved,
+			UserName: user,
+			MediaID:  mediaID,
+			Previous: &prev,
+			Current:  Update{UserName: user, MediaID: mediaID, MediaType: mediaType},
+		})
+	}
+
+	return events
+}
+
+func equalIntPtr(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,139 @@
+package anilistgo
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatcherPoll(t *testing.T) {
+	one, five, six := 1, 5, 6
+	responses := [][]Update{
+		{
+			{UserName: "Ithilias", MediaID: 1, Status: "CURRENT", Progress: &five, UpdatedTime: 100},
+			{UserName: "Ithilias", MediaID: 2, Status: "CURRENT", Progress: &one, UpdatedTime: 100},
+		},
+		{
+			{UserName: "Ithilias", MediaID: 1, Status: "COMPLETED", Progress: &six, Score: 80, UpdatedTime: 200},
+			{UserName: "Ithilias", MediaID: 3, Status: "PLANNING", UpdatedTime: 200},
+		},
+	}
+
+	store := &JSONFileStore{Path: filepath.Join(t.TempDir(), "state.json")}
+	watcher := NewWatcher([]string{"Ithilias"}, MediaTypeAnime, store)
+	call := 0
+	watcher.fetch = func(username string, mediaType string) ([]Update, error) {
+		call++
+		return responses[call-1], nil
+	}
+
+	events, err := watcher.Poll()
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("expected no events on the first poll but got %v", events)
+	}
+
+	events, err = watcher.Poll()
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	expected := []struct {
+		eventType EventType
+		mediaID   int
+	}{
+		{EventProgressChanged, 1},
+		{EventStatusChanged, 1},
+		{EventScoreChanged, 1},
+		{EventAdded, 3},
+		{EventRemoved, 2},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events but got %d: %v", len(expected), len(events), events)
+	}
+	for i, e := range expected {
+		if events[i].Type != e.eventType || events[i].MediaID != e.mediaID {
+			t.Errorf("expected event %s for %d but got %s for %d", e.eventType, e.mediaID, events[i].Type, events[i].MediaID)
+		}
+	}
+
+	saved, err := store.Load("Ithilias")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if len(saved) != 2 || *saved[1].Progress != 6 {
+		t.Errorf("expected saved state for the second poll but got %v", saved)
+	}
+}
+
+type memoryStateStore map[string]map[int]WatchedEntry
+
+func (s memoryStateStore) Load(userName string) (map[int]WatchedEntry, error) {
+	return s[userName], nil
+}
+
+func (s memoryStateStore) Save(userName string, entries map[int]WatchedEntry) error {
+	s[userName] = entries
+	return nil
+}
+
+func TestWatcherRunSkipsFailingUsers(t *testing.T) {
+	store := memoryStateStore{
+		"first":  {1: {UpdatedTime: 100, Status: "CURRENT"}},
+		"second": {1: {UpdatedTime: 100, Status: "CURRENT"}},
+	}
+	watcher := NewWatcher([]string{"first", "second"}, MediaTypeAnime, store)
+	watcher.Interval = time.Hour
+	watcher.fetch = func(username string, mediaType string) ([]Update, error) {
+		if username == "second" {
+			return nil, errors.New("temporary failure")
+		}
+		return []Update{{UserName: username, MediaID: 1, Status: "COMPLETED", UpdatedTime: 200}}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var failed []string
+	watcher.OnError = func(userName string, err error) {
+		failed = append(failed, userName)
+		cancel()
+	}
+
+	events := make(chan Event, 10)
+	if err := watcher.Run(ctx, events); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled but got %v", err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("expected the event of the first user but got %d events", len(events))
+	}
+	if event := <-events; event.UserName != "first" || event.Type != EventStatusChanged {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if len(failed) != 1 || failed[0] != "second" {
+		t.Errorf("expected the error of the second user but got %v", failed)
+	}
+	if store["first"][1].Status != "COMPLETED" || store["second"][1].Status != "CURRENT" {
+		t.Errorf("expected only the first user to be saved but got %v", store)
+	}
+}
+
+func TestWatcherRunKeepsStateOfUndeliveredEvents(t *testing.T) {
+	store := memoryStateStore{"first": {1: {UpdatedTime: 100, Status: "CURRENT"}}}
+	watcher := NewWatcher([]string{"first"}, MediaTypeAnime, store)
+	watcher.fetch = func(username string, mediaType string) ([]Update, error) {
+		return []Update{{UserName: username, MediaID: 1, Status: "COMPLETED", UpdatedTime: 200}}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := watcher.Run(ctx, make(chan Event)); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled but got %v", err)
+	}
+	if store["first"][1].Status != "CURRENT" {
+		t.Errorf("expected the state not to be saved before its events were delivered but got %v", store["first"])
+	}
+}